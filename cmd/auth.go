@@ -0,0 +1,155 @@
+package cmd
+
+import (
+	"bufio"
+	"errors"
+	"fmt"
+	"git-ai-commit/internal/config"
+	"git-ai-commit/internal/credentials"
+	"os"
+	"strings"
+	"text/tabwriter"
+
+	"github.com/spf13/cobra"
+	"golang.org/x/term"
+)
+
+// newAuthCommand는 API 키 저장소를 관리하는 auth 명령어를 생성합니다.
+func newAuthCommand(opts *globalOptions) *cobra.Command {
+	auth := &cobra.Command{
+		Use:   "auth",
+		Short: "API 키를 암호화된 credentials 파일에 저장하거나 삭제",
+		Long: `제공자 API 키를 ~/.git-ai-commit/credentials.json에 암호화해 저장합니다.
+환경 변수나 설정 파일에 같은 제공자의 키가 없을 때 사용합니다.`,
+	}
+
+	// login과 logout은 제공자 이름 하나를 받음 (claude는 anthropic의 별칭)
+	providerArgs := func(run func(r *RootCommand, store credentials.Store, provider string) error) func(*cobra.Command, []string) error {
+		return func(cmd *cobra.Command, args []string) error {
+			provider, err := credentials.NormalizeProvider(args[0])
+			if err != nil {
+				return err
+			}
+			r, err := opts.load()
+			if err != nil {
+				return err
+			}
+			store, err := credentials.DefaultStore()
+			if err != nil {
+				return err
+			}
+			return run(r, store, provider)
+		}
+	}
+
+	auth.AddCommand(
+		&cobra.Command{
+			Use:       "login <provider>",
+			Short:     "API 키 입력 후 저장 (입력한 키는 화면에 표시하지 않음)",
+			Args:      cobra.ExactArgs(1),
+			ValidArgs: credentials.Providers,
+			RunE:      providerArgs((*RootCommand).authLogin),
+		},
+		&cobra.Command{
+			Use:       "logout <provider>",
+			Short:     "저장된 API 키 삭제",
+			Args:      cobra.ExactArgs(1),
+			ValidArgs: credentials.Providers,
+			RunE:      providerArgs((*RootCommand).authLogout),
+		},
+		&cobra.Command{
+			Use:   "status",
+			Short: "제공자별 API 키 설정 여부와 출처 출력",
+			Args:  cobra.NoArgs,
+			RunE: func(cmd *cobra.Command, args []string) error {
+				r, err := opts.load()
+				if err != nil {
+					return err
+				}
+				return r.authStatus()
+			},
+		},
+	)
+	return auth
+}
+
+// authLogin은 API 키를 입력받아 저장소에 저장합니다.
+// 터미널이면 입력을 화면에 표시하지 않고, 아니면 표준 입력의 첫 줄을 읽습니다.
+func (r *RootCommand) authLogin(store credentials.Store, provider string) error {
+	lang := r.config.Lang
+
+	fmt.Printf(r.getMessage("prompt_api_key", lang), provider)
+
+	var key string
+	if term.IsTerminal(int(os.Stdin.Fd())) {
+		input, err := term.ReadPassword(int(os.Stdin.Fd()))
+		fmt.Println()
+		if err != nil {
+			return fmt.Errorf("%s: %w", r.getMessage("error_read_api_key", lang), err)
+		}
+		key = string(input)
+	} else {
+		input, err := bufio.NewReader(os.Stdin).ReadString('\n')
+		if err != nil && input == "" {
+			return fmt.Errorf("%s: %w", r.getMessage("error_read_api_key", lang), err)
+		}
+		key = input
+	}
+
+	key = strings.TrimSpace(key)
+	if key == "" {
+		return errors.New(r.getMessage("error_empty_api_key", lang))
+	}
+
+	if err := store.Set(provider, key); err != nil {
+		return fmt.Errorf("%s: %w", r.getMessage("error_save_api_key", lang), err)
+	}
+	fmt.Printf("✅ "+r.getMessage("api_key_saved", lang)+"\n", provider, store.Location())
+
+	// 환경 변수나 설정 파일의 키가 저장된 키보다 우선함을 알림
+	for _, value := range r.config.Values() {
+		if value.Key == provider+"_api_key" && value.Source.Kind > config.SourceCredentials {
+			fmt.Printf("⚠️  "+r.getMessage("warning_api_key_overridden", lang)+"\n", value.Source)
+		}
+	}
+	return nil
+}
+
+// authLogout은 저장된 API 키를 삭제합니다.
+func (r *RootCommand) authLogout(store credentials.Store, provider string) error {
+	lang := r.config.Lang
+
+	err := store.Delete(provider)
+	if errors.Is(err, credentials.ErrNotFound) {
+		fmt.Printf(r.getMessage("api_key_not_stored", lang)+"\n", provider)
+		return nil
+	}
+	if err != nil {
+		return fmt.Errorf("%s: %w", r.getMessage("error_delete_api_key", lang), err)
+	}
+
+	fmt.Printf("✅ "+r.getMessage("api_key_deleted", lang)+"\n", provider)
+	return nil
+}
+
+// authStatus는 제공자별 API 키 설정 여부와 출처를 출력합니다.
+func (r *RootCommand) authStatus() error {
+	lang := r.config.Lang
+
+	values := make(map[string]config.Value)
+	for _, value := range r.config.Values() {
+		values[value.Key] = value
+	}
+
+	w := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(w, "PROVIDER\tKEY\tSOURCE")
+	for _, provider := range credentials.Providers {
+		value := values[provider+"_api_key"]
+		if value.Value == "" {
+			fmt.Fprintf(w, "%s\t-\t%s\n", provider, r.getMessage("label_not_set", lang))
+			continue
+		}
+		fmt.Fprintf(w, "%s\t%s\t%s\n", provider, value.Value, value.Source)
+	}
+	return w.Flush()
+}
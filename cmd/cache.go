@@ -0,0 +1,85 @@
+package cmd
+
+import (
+	"fmt"
+	"git-ai-commit/internal/cache"
+
+	"github.com/spf13/cobra"
+)
+
+// newCacheCommand는 마지막으로 선택한 메시지 캐시를 관리하는 cache 명령어를 생성합니다.
+func newCacheCommand(opts *globalOptions) *cobra.Command {
+	cacheCmd := &cobra.Command{
+		Use:   "cache",
+		Short: "마지막으로 선택한 커밋 메시지 캐시 확인 및 삭제",
+		Long: `같은 diff로 다시 실행하면 이전에 선택한 메시지를 "p" 키로 다시 사용할 수 있도록
+마지막으로 선택한 메시지를 ~/.git-ai-commit/cache.json에 저장합니다.`,
+	}
+
+	cacheCmd.AddCommand(
+		&cobra.Command{
+			Use:   "show",
+			Short: "캐시된 메시지 출력",
+			Args:  cobra.NoArgs,
+			RunE: func(cmd *cobra.Command, args []string) error {
+				r, err := opts.load()
+				if err != nil {
+					return err
+				}
+				return r.showCache()
+			},
+		},
+		&cobra.Command{
+			Use:   "clear",
+			Short: "캐시 삭제",
+			Args:  cobra.NoArgs,
+			RunE: func(cmd *cobra.Command, args []string) error {
+				r, err := opts.load()
+				if err != nil {
+					return err
+				}
+				return r.clearCache()
+			},
+		},
+	)
+	return cacheCmd
+}
+
+// showCache는 캐시된 메시지와 저장 시간을 출력합니다.
+func (r *RootCommand) showCache() error {
+	lang := r.config.Lang
+
+	cacheManager, err := cache.NewCacheManager()
+	if err != nil {
+		return err
+	}
+
+	cached, err := cacheManager.Latest()
+	if err != nil {
+		return err
+	}
+	if cached == nil {
+		fmt.Println(r.getMessage("cache_empty", lang))
+		return nil
+	}
+
+	fmt.Printf("%s: %s\n", r.getMessage("label_cache_file", lang), cacheManager.Path())
+	fmt.Printf("%s: %s\n", r.getMessage("label_saved_at", lang), cached.Timestamp.Local().Format("2006-01-02 15:04:05"))
+	fmt.Printf("%s: %.12s\n\n", r.getMessage("label_diff_hash", lang), cached.DiffHash)
+	fmt.Println(cached.Message)
+	return nil
+}
+
+// clearCache는 캐시를 삭제합니다.
+func (r *RootCommand) clearCache() error {
+	cacheManager, err := cache.NewCacheManager()
+	if err != nil {
+		return err
+	}
+	if err := cacheManager.Clear(); err != nil {
+		return err
+	}
+
+	fmt.Println("✅ " + r.getMessage("cache_cleared", r.config.Lang))
+	return nil
+}
@@ -0,0 +1,63 @@
+package cmd
+
+import (
+	"fmt"
+	"git-ai-commit/internal/config"
+	"os"
+	"text/tabwriter"
+
+	"github.com/spf13/cobra"
+)
+
+// newConfigCommand는 최종 설정을 출력하는 config 명령어를 생성합니다.
+func newConfigCommand(opts *globalOptions) *cobra.Command {
+	return &cobra.Command{
+		Use:   "config",
+		Short: "최종 설정 값과 각 값의 출처 출력",
+		Long: `기본값, 저장소 설정 파일, 사용자 설정 파일, git config, 환경 변수, 명령줄 옵션을
+이 순서대로 합친 최종 설정과 각 값을 어디서 가져왔는지 출력합니다 (뒤의 값이 앞의 값을 덮어씀).
+API 키는 마지막 4글자만 표시합니다.`,
+		Args: cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			r, err := opts.load()
+			if err != nil {
+				return err
+			}
+			return r.PrintConfig()
+		},
+	}
+}
+
+// PrintConfig는 최종 설정 값과 각 값의 출처를 출력합니다.
+// API 키는 마지막 4글자만 표시합니다.
+func (r *RootCommand) PrintConfig() error {
+	lang := r.config.Lang
+
+	fmt.Println("⚙️  " + r.getMessage("config_title", lang))
+	if path, err := config.UserConfigPath(); err == nil {
+		fmt.Printf("   %s: %s%s\n", r.getMessage("label_user_config", lang), path, r.fileStatus(path, lang))
+	}
+	if path := config.RepoConfigPath(); path != "" {
+		fmt.Printf("   %s: %s%s\n", r.getMessage("label_repo_config", lang), path, r.fileStatus(path, lang))
+	}
+	fmt.Println()
+
+	w := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(w, "KEY\tVALUE\tSOURCE")
+	for _, value := range r.config.Values() {
+		text := value.Value
+		if text == "" {
+			text = "-"
+		}
+		fmt.Fprintf(w, "%s\t%s\t%s\n", value.Key, text, value.Source)
+	}
+	return w.Flush()
+}
+
+// fileStatus는 설정 파일이 없으면 "(없음)" 표시를, 있으면 빈 문자열을 반환합니다.
+func (r *RootCommand) fileStatus(path, lang string) string {
+	if _, err := os.Stat(path); err != nil {
+		return " (" + r.getMessage("label_not_found", lang) + ")"
+	}
+	return ""
+}
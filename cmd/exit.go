@@ -0,0 +1,29 @@
+package cmd
+
+import "fmt"
+
+// 종료 코드 (1은 그 외 모든 에러)
+const (
+	ExitNoStagedChanges = 2 // staged된 변경 사항이 없음
+	ExitProviderError   = 3 // LLM 제공자 생성 또는 호출 실패 (시간 초과 포함)
+	ExitUserQuit        = 4 // 사용자가 종료를 선택했거나 Ctrl+C로 취소함
+	ExitLintFailed      = 5 // lint 명령어에서 커밋 메시지가 규칙을 위반함
+)
+
+// ExitError는 특정 종료 코드로 끝내야 하는 에러입니다.
+// 스크립트와 CI가 실패 원인을 구분할 수 있도록 main에서 Code로 종료합니다.
+type ExitError struct {
+	Code int
+	Err  error // 출력할 에러 (nil이면 이미 안내를 출력했으므로 에러 메시지 없이 종료)
+}
+
+func (e *ExitError) Error() string {
+	if e.Err == nil {
+		return fmt.Sprintf("exit status %d", e.Code)
+	}
+	return e.Err.Error()
+}
+
+func (e *ExitError) Unwrap() error {
+	return e.Err
+}
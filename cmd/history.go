@@ -0,0 +1,103 @@
+package cmd
+
+import (
+	"fmt"
+	"git-ai-commit/internal/cache"
+	"git-ai-commit/internal/git"
+	"os"
+	"strings"
+	"text/tabwriter"
+
+	"github.com/spf13/cobra"
+)
+
+// 기본으로 출력하는 기록 수
+const defaultHistoryLimit = 20
+
+// newHistoryCommand는 커밋한 메시지 기록을 보여주는 history 명령어를 생성합니다.
+func newHistoryCommand(opts *globalOptions) *cobra.Command {
+	var limit int
+	var all bool
+
+	history := &cobra.Command{
+		Use:   "history",
+		Short: "git-ai-commit으로 커밋한 메시지 기록 출력 (최신순)",
+		Long: `git-ai-commit으로 커밋한 메시지를 최신순으로 출력합니다.
+기본적으로 현재 저장소의 기록만 보여주며, --all이면 모든 저장소의 기록을 보여줍니다.`,
+		Args: cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			r, err := opts.load()
+			if err != nil {
+				return err
+			}
+			return r.printHistory(limit, all)
+		},
+	}
+	history.Flags().IntVarP(&limit, "limit", "n", defaultHistoryLimit, "출력할 최대 기록 수 (0이면 전부)")
+	history.Flags().BoolVar(&all, "all", false, "모든 저장소의 기록 출력")
+
+	history.AddCommand(&cobra.Command{
+		Use:   "clear",
+		Short: "기록 삭제",
+		Args:  cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			r, err := opts.load()
+			if err != nil {
+				return err
+			}
+			return r.clearHistory()
+		},
+	})
+	return history
+}
+
+// printHistory는 커밋한 메시지 기록을 최신순으로 출력합니다.
+// 여러 줄 메시지는 제목 줄만 출력합니다.
+func (r *RootCommand) printHistory(limit int, all bool) error {
+	lang := r.config.Lang
+
+	history, err := cache.NewHistory()
+	if err != nil {
+		return err
+	}
+
+	repo := ""
+	if !all {
+		// 저장소 밖에서는 모든 기록을 출력
+		repo, _ = git.GetRepoRoot()
+	}
+
+	entries, err := history.Recent(repo, limit)
+	if err != nil {
+		return err
+	}
+	if len(entries) == 0 {
+		fmt.Println(r.getMessage("history_empty", lang))
+		return nil
+	}
+
+	w := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+	for _, entry := range entries {
+		subject, _, _ := strings.Cut(entry.Message, "\n")
+		if repo == "" {
+			fmt.Fprintf(w, "%s\t%s\t%s\n", entry.Timestamp.Local().Format("2006-01-02 15:04"), entry.Repo, subject)
+		} else {
+			fmt.Fprintf(w, "%s\t%s\t%s\n", entry.Timestamp.Local().Format("2006-01-02 15:04"), entry.Provider, subject)
+		}
+	}
+	return w.Flush()
+}
+
+// clearHistory는 기록을 모두 삭제합니다.
+func (r *RootCommand) clearHistory() error {
+	history, err := cache.NewHistory()
+	if err != nil {
+		return err
+	}
+	if err := history.Clear(); err != nil {
+		return err
+	}
+
+	fmt.Println("✅ " + r.getMessage("history_cleared", r.config.Lang))
+	return nil
+}
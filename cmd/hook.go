@@ -0,0 +1,289 @@
+package cmd
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"git-ai-commit/internal/git"
+	"git-ai-commit/internal/lint"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/spf13/cobra"
+)
+
+const (
+	hookName       = "prepare-commit-msg"
+	hookBackupExt  = ".pre-ai-commit" // 설치 전에 있던 훅을 옮겨 두는 파일 확장자
+	hookMarker     = "# installed by git-ai-commit"
+	hookFileHeader = "#!/bin/sh\n" + hookMarker + ` (git ai-commit hook uninstall로 제거)
+#
+# git commit 실행 시 staged된 변경 사항으로 커밋 메시지를 생성해 편집기에 미리 채웁니다.
+# 메시지 생성에 실패해도 커밋은 계속 진행합니다.
+`
+)
+
+// newHookCommand는 git 훅 연동을 관리하는 hook 명령어를 생성합니다.
+func newHookCommand(opts *globalOptions) *cobra.Command {
+	hook := &cobra.Command{
+		Use:   "hook",
+		Short: "git 훅 연동 관리",
+		Long: `git commit 실행 시 커밋 메시지를 자동으로 채우는 prepare-commit-msg 훅을 관리합니다.
+이미 설치된 훅이 있으면 prepare-commit-msg.pre-ai-commit으로 옮기고 먼저 실행합니다.`,
+	}
+
+	hook.AddCommand(
+		&cobra.Command{
+			Use:   "install",
+			Short: "현재 저장소에 prepare-commit-msg 훅 설치",
+			Args:  cobra.NoArgs,
+			RunE: func(cmd *cobra.Command, args []string) error {
+				r, err := opts.load()
+				if err != nil {
+					return err
+				}
+				return r.installHook()
+			},
+		},
+		&cobra.Command{
+			Use:   "uninstall",
+			Short: "설치한 훅을 제거하고 기존 훅 복원",
+			Args:  cobra.NoArgs,
+			RunE: func(cmd *cobra.Command, args []string) error {
+				r, err := opts.load()
+				if err != nil {
+					return err
+				}
+				return r.uninstallHook()
+			},
+		},
+		&cobra.Command{
+			Use:    "run <message-file> [source] [sha]",
+			Short:  "prepare-commit-msg 훅에서 호출하는 진입점",
+			Hidden: true,
+			Args:   cobra.RangeArgs(1, 3),
+			RunE: func(cmd *cobra.Command, args []string) error {
+				// 훅은 커밋을 막으면 안 되므로 모든 에러를 경고로만 출력
+				source := ""
+				if len(args) > 1 {
+					source = args[1]
+				}
+				r, err := opts.load()
+				if err == nil {
+					err = r.runHook(args[0], source)
+				}
+				if err != nil {
+					fmt.Fprintf(os.Stderr, "⚠️  git-ai-commit: %v\n", err)
+				}
+				return nil
+			},
+		},
+	)
+	return hook
+}
+
+// hookScript는 설치할 훅 스크립트를 반환합니다.
+// 기존 훅이 있으면 먼저 실행하고, 실패하면 그 종료 코드로 커밋을 중단합니다.
+func hookScript(executable string) string {
+	return hookFileHeader + `
+hook_dir=$(dirname "$0")
+if [ -x "$hook_dir/` + hookName + hookBackupExt + `" ]; then
+	"$hook_dir/` + hookName + hookBackupExt + `" "$@" || exit $?
+fi
+
+` + git.ShellQuote(executable) + ` hook run "$@" || true
+`
+}
+
+// isOwnHook은 파일이 git-ai-commit이 설치한 훅인지 확인합니다.
+func isOwnHook(path string) (bool, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return false, err
+	}
+	return bytes.Contains(data, []byte(hookMarker)), nil
+}
+
+// installHook은 prepare-commit-msg 훅을 설치합니다.
+// 다른 훅이 이미 있으면 지우지 않고 .pre-ai-commit 파일로 옮겨 새 훅에서 먼저 실행합니다.
+func (r *RootCommand) installHook() error {
+	lang := r.config.Lang
+
+	dir, err := git.GetHooksDir()
+	if err != nil {
+		return err
+	}
+	executable, err := os.Executable()
+	if err != nil {
+		return fmt.Errorf("%s: %w", r.getMessage("error_hook_executable", lang), err)
+	}
+	if resolved, err := filepath.EvalSymlinks(executable); err == nil {
+		executable = resolved
+	}
+
+	path := filepath.Join(dir, hookName)
+	backup := path + hookBackupExt
+
+	own, err := isOwnHook(path)
+	switch {
+	case errors.Is(err, os.ErrNotExist):
+		// 새로 설치
+	case err != nil:
+		return err
+	case own:
+		// 이미 설치된 훅은 실행 파일 경로만 갱신
+	default:
+		if _, err := os.Stat(backup); err == nil {
+			return fmt.Errorf(r.getMessage("error_hook_backup_exists", lang), backup)
+		}
+		if err := os.Rename(path, backup); err != nil {
+			return err
+		}
+		fmt.Printf("📦 "+r.getMessage("hook_existing_moved", lang)+"\n", backup)
+	}
+
+	if err := os.MkdirAll(dir, 0755); err != nil {
+		return err
+	}
+	if err := os.WriteFile(path, []byte(hookScript(executable)), 0755); err != nil {
+		return err
+	}
+	// 이미 있던 파일은 WriteFile이 권한을 바꾸지 않음
+	if err := os.Chmod(path, 0755); err != nil {
+		return err
+	}
+
+	fmt.Printf("✅ "+r.getMessage("hook_installed", lang)+"\n", path)
+	return nil
+}
+
+// uninstallHook은 설치한 훅을 제거하고 옮겨 두었던 기존 훅을 복원합니다.
+// git-ai-commit이 설치하지 않은 훅은 건드리지 않습니다.
+func (r *RootCommand) uninstallHook() error {
+	lang := r.config.Lang
+
+	dir, err := git.GetHooksDir()
+	if err != nil {
+		return err
+	}
+	path := filepath.Join(dir, hookName)
+	backup := path + hookBackupExt
+
+	own, err := isOwnHook(path)
+	if errors.Is(err, os.ErrNotExist) || (err == nil && !own) {
+		fmt.Println(r.getMessage("hook_not_installed", lang))
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+
+	if err := os.Remove(path); err != nil {
+		return err
+	}
+	if _, err := os.Stat(backup); err == nil {
+		if err := os.Rename(backup, path); err != nil {
+			return err
+		}
+		fmt.Printf("📦 "+r.getMessage("hook_existing_restored", lang)+"\n", path)
+	}
+
+	fmt.Println("✅ " + r.getMessage("hook_uninstalled", lang))
+	return nil
+}
+
+// runHook은 prepare-commit-msg 훅에서 커밋 메시지를 생성해 메시지 파일 앞에 채웁니다.
+// 1번 후보를 메시지로 쓰고 나머지 후보는 주석으로 덧붙이며, 기존 내용(git의 안내 주석)은 그대로 둡니다.
+// -m/-F 메시지, 커밋 템플릿, merge, squash, --amend/-c/-C(source "commit")는 이미 메시지가 있으므로 건너뜁니다.
+func (r *RootCommand) runHook(messageFile, source string) error {
+	lang := r.config.Lang
+	r.out = os.Stderr
+
+	switch source {
+	case "message", "template", "merge", "squash", "commit":
+		return nil
+	}
+
+	diffResult, err := git.GetCachedDiff()
+	if err != nil {
+		return fmt.Errorf("%s: %w", r.getMessage("error_diff_failed", lang), err)
+	}
+	if len(diffResult.Files) == 0 {
+		return nil
+	}
+	diffResult.Scopes = allowedOnly(diffResult.Scopes, r.config.AllowedScopes)
+
+	chain := r.config.GetProviderChain()
+	provider, err := r.newProvider(chain, lang)
+	if err != nil {
+		return err
+	}
+	defer provider.Close()
+
+	fmt.Fprintf(r.out, "🤖 git-ai-commit: %s (%s)\n", r.getMessage("generating_messages", lang), strings.Join(provider.Names(), " → "))
+
+	ctx, cancel := context.WithTimeout(context.Background(), r.config.Timeout)
+	defer cancel()
+
+	input := r.newGeneratorInput(diffResult, chain)
+	generator := r.newGenerator(provider)
+
+	// 요약에 실패하면 잘라낸 diff로 계속 진행
+	if generator.NeedsSummary(diffResult) {
+		if summaries, err := generator.Summarize(ctx, input); err == nil {
+			input.Summaries = summaries
+		} else {
+			r.verbosef("summary: %v", err)
+		}
+	}
+
+	messages, err := generator.Generate(ctx, input)
+	if err != nil {
+		if errors.Is(ctx.Err(), context.DeadlineExceeded) {
+			return fmt.Errorf(r.getMessage("error_generate_timeout", lang), r.config.Timeout)
+		}
+		return fmt.Errorf("%s: %w", r.getMessage("error_generate_failed", lang), err)
+	}
+	if len(messages) == 0 {
+		return nil
+	}
+
+	existing, err := os.ReadFile(messageFile)
+	if err != nil {
+		return err
+	}
+	return os.WriteFile(messageFile, []byte(r.hookMessage(messages, string(existing))), 0644)
+}
+
+// hookMessage는 1번 후보, 주석으로 처리한 나머지 후보, 기존 메시지 파일 내용을 이어 붙입니다.
+// 주석은 git이 지우도록 core.commentChar에 설정한 문자(auto이면 기존 안내 주석의 문자)로 씁니다.
+func (r *RootCommand) hookMessage(messages []string, existing string) string {
+	comment := lint.CommentChar(r.commentSetting(), existing)
+
+	var builder strings.Builder
+	builder.WriteString(strings.TrimRight(messages[0], " \t\n"))
+	builder.WriteString("\n\n")
+
+	if len(messages) > 1 {
+		builder.WriteString(comment + " " + r.getMessage("hook_other_candidates", r.config.Lang) + "\n")
+		for i, message := range messages[1:] {
+			builder.WriteString(comment + "\n")
+			for j, line := range strings.Split(strings.TrimRight(message, " \t\n"), "\n") {
+				prefix := comment + "    "
+				if j == 0 {
+					prefix = fmt.Sprintf("%s %d) ", comment, i+2)
+				}
+				builder.WriteString(strings.TrimRight(prefix+line, " ") + "\n")
+			}
+		}
+	}
+
+	// git의 안내 주석은 뒤에 유지
+	if existing = strings.TrimLeft(existing, "\n"); existing != "" {
+		builder.WriteString(comment + "\n")
+		builder.WriteString(existing)
+	}
+	return builder.String()
+}
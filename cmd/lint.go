@@ -0,0 +1,105 @@
+package cmd
+
+import (
+	"fmt"
+	"git-ai-commit/internal/lint"
+	"git-ai-commit/internal/model"
+	"io"
+	"os"
+	"slices"
+
+	"github.com/spf13/cobra"
+)
+
+// newLintCommand는 커밋 메시지를 검사하는 lint 명령어를 생성합니다.
+func newLintCommand(opts *globalOptions) *cobra.Command {
+	return &cobra.Command{
+		Use:   "lint [message-file]",
+		Short: "커밋 메시지 검사 (commit-msg 훅에서 사용)",
+		Long: `커밋 메시지가 Conventional Commit 형식, 허용한 타입과 scope, 제목 길이, 명령형, 마침표,
+제목 다음 빈 줄 규칙을 지키는지 검사합니다. 파일을 지정하지 않거나 "-"이면 표준 입력을 읽습니다.
+'#'로 시작하는 줄은 무시하므로 commit-msg 훅에서 "git ai-commit lint \"$1\""로 사용할 수 있습니다.
+
+종료 코드: 0 위반 없음, 5 규칙 위반`,
+		Args: cobra.MaximumNArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			path := "-"
+			if len(args) > 0 {
+				path = args[0]
+			}
+			r, err := opts.load()
+			if err != nil {
+				return err
+			}
+			return r.lintFile(path)
+		},
+	}
+}
+
+// lintFile은 파일(또는 "-"이면 표준 입력)의 커밋 메시지를 검사하고 위반 사항을 출력합니다.
+// 위반이 있으면 ExitLintFailed로 종료합니다.
+func (r *RootCommand) lintFile(path string) error {
+	lang := r.config.Lang
+
+	var data []byte
+	var err error
+	if path == "-" {
+		data, err = io.ReadAll(os.Stdin)
+	} else {
+		data, err = os.ReadFile(path)
+	}
+	if err != nil {
+		return fmt.Errorf("%s: %w", r.getMessage("error_read_message", lang), err)
+	}
+
+	message := lint.StripComments(string(data), lint.CommentChar(r.commentSetting(), string(data)))
+	if message == "" {
+		fmt.Fprintln(os.Stderr, "❌ "+r.getMessage("error_lint_empty", lang))
+		return &ExitError{Code: ExitLintFailed}
+	}
+
+	violations := r.linter(r.lintRules(r.loadStyle()))(message)
+	if len(violations) == 0 {
+		fmt.Println("✅ " + r.getMessage("lint_passed", lang))
+		return nil
+	}
+
+	fmt.Fprintf(os.Stderr, "❌ "+r.getMessage("lint_failed", lang)+"\n", len(violations))
+	for _, v := range violations {
+		fmt.Fprintf(os.Stderr, "   - %s\n", v)
+	}
+	return &ExitError{Code: ExitLintFailed}
+}
+
+// lintRules는 설정과 저장소 스타일에 맞는 검사 규칙을 반환합니다.
+// 저장소 스타일이 Conventional Commit이 아니거나 제목 앞에 gitmoji를 붙이면 형식 규칙을,
+// 제목 끝에 마침표를 쓰면 마침표 규칙을 검사하지 않습니다.
+func (r *RootCommand) lintRules(profile *model.StyleProfile) lint.Rules {
+	rules := lint.Rules{
+		Types:            r.config.AllowedTypes,
+		Scopes:           r.config.AllowedScopes,
+		MaxSubjectLength: r.config.MaxSubjectLength,
+		Disabled:         slices.Clone(r.config.LintDisable),
+	}
+	if profile != nil && (!profile.Conventional || profile.Gitmoji) {
+		rules.Disabled = append(rules.Disabled, lint.RuleConventional)
+	}
+	if profile != nil && profile.TrailingPeriod {
+		rules.Disabled = append(rules.Disabled, lint.RuleTrailingPeriod)
+	}
+	return rules
+}
+
+// linter는 rules로 메시지를 검사하는 함수를 반환합니다.
+func (r *RootCommand) linter(rules lint.Rules) func(message string) []lint.Violation {
+	return func(message string) []lint.Violation {
+		return lint.Lint(message, rules, r.config.Lang)
+	}
+}
+
+// printViolations는 선택 화면 없이 고른 메시지의 규칙 위반을 경고로 출력합니다.
+func (r *RootCommand) printViolations(violations []lint.Violation) {
+	for _, v := range violations {
+		fmt.Fprintf(r.out, "⚠️  %s\n", v.Message)
+	}
+}
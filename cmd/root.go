@@ -1,41 +1,74 @@
 package cmd
 
 import (
-	"flag"
+	"context"
+	"encoding/json"
+	"errors"
 	"fmt"
 	"git-ai-commit/internal/cache"
 	"git-ai-commit/internal/config"
 	"git-ai-commit/internal/core"
 	"git-ai-commit/internal/git"
 	"git-ai-commit/internal/llm"
+	"git-ai-commit/internal/model"
+	"git-ai-commit/internal/style"
+	"git-ai-commit/internal/ticket"
 	"git-ai-commit/internal/ui"
 	"git-ai-commit/internal/version"
 	"os"
+	"os/signal"
+	"strings"
+	"time"
+
+	"github.com/spf13/cobra"
 )
 
 // RootCommand는 메인 명령어입니다.
 type RootCommand struct {
-	config *config.Config
-	detail string
-	lang   string
+	config  *config.Config
+	verbose bool
+	out     *os.File // 진행 상황 출력 (결과를 stdout으로 내보내는 모드에서는 stderr)
 }
 
 // NewRootCommand는 새로운 RootCommand 인스턴스를 생성합니다.
-func NewRootCommand(cfg *config.Config, detail string, lang string) *RootCommand {
+// 언어와 디테일 레벨은 cfg에서 가져옵니다 (명령줄 옵션은 config.Load에 전달).
+func NewRootCommand(cfg *config.Config, verbose bool) *RootCommand {
 	return &RootCommand{
-		config: cfg,
-		detail: detail,
-		lang:   lang,
+		config:  cfg,
+		verbose: verbose,
+		out:     os.Stdout,
 	}
 }
 
+// RunOptions는 generate 명령어의 실행 방식입니다.
+// 아무것도 지정하지 않으면 선택 화면을 띄우고, 지정하면 입력 없이 실행합니다.
+type RunOptions struct {
+	Yes   bool // 선택 화면 없이 Pick번 후보(기본 1번)로 커밋
+	Pick  int  // 사용할 후보 번호 (1부터, 0이면 지정 안 함). Print가 아니면 Yes와 같이 커밋
+	Print bool // 커밋하지 않고 선택한 메시지만 stdout으로 출력
+	JSON  bool // 커밋하지 않고 모든 후보를 JSON으로 stdout에 출력
+
+	DryRun     bool // 메시지 선택까지 진행하고 실행할 git commit 명령어만 출력
+	AllowEmpty bool // staged된 변경 사항이 없어도 커밋 (git commit --allow-empty)
+}
+
+// interactive는 선택 화면을 띄워야 하는지 확인합니다.
+func (o RunOptions) interactive() bool {
+	return !o.Yes && o.Pick == 0 && !o.Print && !o.JSON
+}
+
 // Run은 메인 명령어를 실행합니다.
-func (r *RootCommand) Run() error {
+func (r *RootCommand) Run(opts RunOptions) error {
 	// 언어 설정 확인
-	lang := r.getLanguage()
+	lang := r.config.Lang
+
+	// 결과를 stdout으로 출력하는 모드에서는 진행 상황을 stderr로 출력
+	if opts.Print || opts.JSON {
+		r.out = os.Stderr
+	}
 
-	fmt.Println("🤖 Git AI Commit")
-	fmt.Println("================")
+	fmt.Fprintln(r.out, "🤖 Git AI Commit")
+	fmt.Fprintln(r.out, "================")
 
 	// 1. staged된 파일 확인
 	files, err := git.GetStagedFiles()
@@ -43,15 +76,19 @@ func (r *RootCommand) Run() error {
 		return fmt.Errorf("%s: %w", r.getMessage("error_staged_failed", lang), err)
 	}
 
-	if len(files) == 0 {
-		fmt.Println("\n❌ " + r.getMessage("error_no_staged_files", lang))
-		fmt.Println(r.getMessage("hint_use_git_add", lang))
-		return nil
+	if len(files) == 0 && !opts.AllowEmpty {
+		fmt.Fprintln(r.out, "\n❌ "+r.getMessage("error_no_staged_files", lang))
+		fmt.Fprintln(r.out, r.getMessage("hint_use_git_add", lang))
+		return &ExitError{Code: ExitNoStagedChanges}
 	}
 
-	fmt.Printf("\n✅ %s\n", r.formatFileCount(len(files), lang))
-	for _, file := range files {
-		fmt.Printf("  - %s\n", file)
+	if len(files) == 0 {
+		fmt.Fprintln(r.out, "\n⚪ "+r.getMessage("label_empty_commit", lang))
+	} else {
+		fmt.Fprintf(r.out, "\n✅ %s\n", r.formatFileCount(len(files), lang))
+		for _, file := range files {
+			fmt.Fprintf(r.out, "  - %s\n", file)
+		}
 	}
 
 	// 2. diff 분석 및 파싱
@@ -60,12 +97,26 @@ func (r *RootCommand) Run() error {
 		return fmt.Errorf("%s: %w", r.getMessage("error_diff_failed", lang), err)
 	}
 
+	// 빈 커밋은 변경된 파일이 없으므로 chore로 추천
+	if diffResult.CommitType == "" {
+		diffResult.CommitType = git.InferCommitType(diffResult.Files)
+	}
+
 	// diff hash 계산
 	diffHash := git.CalculateDiffHash(diffResult.RawDiff)
 
-	fmt.Printf("\n📊 %s: %s\n", r.getMessage("label_recommended_type", lang), diffResult.CommitType)
+	// 프로젝트에서 허용하지 않는 scope는 추천하지 않음
+	diffResult.Scopes = allowedOnly(diffResult.Scopes, r.config.AllowedScopes)
+
+	fmt.Fprintf(r.out, "\n📊 %s: %s\n", r.getMessage("label_recommended_type", lang), diffResult.SuggestedType())
 	if len(diffResult.Scopes) > 0 {
-		fmt.Printf("   %s: %s\n", r.getMessage("label_recommended_scope", lang), diffResult.Scopes)
+		fmt.Fprintf(r.out, "   %s: %s\n", r.getMessage("label_recommended_scope", lang), diffResult.Scopes)
+	}
+	if diffResult.Breaking {
+		fmt.Fprintf(r.out, "💥 %s:\n", r.getMessage("label_breaking", lang))
+		for _, change := range diffResult.BreakingChanges {
+			fmt.Fprintf(r.out, "   - %s\n", core.DescribeBreakingChange(change, lang))
+		}
 	}
 
 	// 3. 캐시 매니저 초기화 및 이전 메시지 로드
@@ -80,169 +131,642 @@ func (r *RootCommand) Run() error {
 		prevMessage = cachedData.Message
 	}
 
-	// 4. 사용할 모델 결정
-	model := r.config.Model
-	if model == "" {
-		model = r.config.GetFirstAvailableModel()
+	// 4. 사용할 제공자 체인 결정 및 생성
+	chain := r.config.GetProviderChain()
+	provider, err := r.newProvider(chain, lang)
+	if err != nil {
+		return &ExitError{Code: ExitProviderError, Err: err}
 	}
+	defer provider.Close()
+
+	fmt.Fprintf(r.out, "🤖 %s: %s\n", r.getMessage("label_using_model", lang), strings.Join(provider.Names(), " → "))
+
+	// 5. 커밋 메시지 생성
+	detail := r.config.Detail
+	fmt.Fprintf(r.out, "📝 %s: %s\n", r.getMessage("label_detail_level", lang), detail)
 
-	if model == "" {
-		return fmt.Errorf(r.getMessage("error_no_api_key", lang))
+	input := r.newGeneratorInput(diffResult, chain)
+	generator := r.newGenerator(provider)
+	if len(input.Tickets) > 0 {
+		fmt.Fprintf(r.out, "🎫 %s: %s\n", r.getMessage("label_tickets", lang), strings.Join(input.Tickets, ", "))
 	}
 
-	fmt.Printf("🤖 %s: %s\n", r.getMessage("label_using_model", lang), model)
+	// 큰 diff는 파일 그룹별 요약을 먼저 생성
+	if generator.NeedsSummary(diffResult) {
+		if err := r.summarize(generator, input, lang); err != nil {
+			return err
+		}
+	}
 
-	// 4. API 키 가져오기
-	apiKey, err := r.config.GetAPIKey(model)
+	// 토큰 예산 확인 (초과 시 우선순위가 낮은 파일은 디렉토리별 요약으로 대체)
+	r.printBudgetReport(core.PlanBudget(input), lang)
+
+	fmt.Fprintln(r.out, "\n🔄 "+r.getMessage("generating_messages", lang))
+	messages, err := r.generate(generator, input, lang)
 	if err != nil {
-		return fmt.Errorf("%s: %w", r.getMessage("error_get_api_key", lang), err)
+		return err
 	}
 
-	// 5. LLM 제공자 생성
-	provider, err := llm.NewProvider(model, apiKey)
+	fmt.Fprintln(r.out, "✅ "+r.getMessage("candidates_generated", lang))
+	fmt.Fprintf(r.out, "   %s: %s\n", r.getMessage("label_generated_by", lang), provider.Used())
+
+	// 6. 후보 선택 (선택 화면 또는 옵션으로 지정한 후보)
+	var selectedMessage string
+	switch {
+	case opts.JSON:
+		return r.printCandidatesJSON(messages, provider.Used(), diffResult)
+	case opts.interactive():
+		selectedMessage, err = r.selectMessage(generator, input, provider, messages, prevMessage)
+	default:
+		selectedMessage, err = r.pickCandidate(messages, opts.Pick)
+		if err == nil {
+			r.printViolations(r.linter(input.LintRules)(selectedMessage))
+		}
+	}
 	if err != nil {
-		return fmt.Errorf("%s: %w", r.getMessage("error_create_provider", lang), err)
+		return err
 	}
 
-	// 5. 커밋 메시지 생성
-	detail := r.getDetailLevel()
-	fmt.Printf("📝 %s: %s\n", r.getMessage("label_detail_level", lang), detail)
-	fmt.Println("\n🔄 " + r.getMessage("generating_messages", lang))
-	generator := core.NewGenerator(provider)
-	messages, err := generator.Generate(diffResult, detail, lang)
-	if err != nil {
-		return fmt.Errorf("%s: %w", r.getMessage("error_generate_failed", lang), err)
+	// 메시지 끝의 빈 줄은 git commit이 어차피 지우므로 미리 정리 (dry-run 출력과 실제 명령어를 같게 유지)
+	request := model.CommitRequest{
+		Message:    strings.TrimRight(selectedMessage, " \t\n"),
+		DryRun:     opts.DryRun,
+		AllowEmpty: opts.AllowEmpty,
+	}
+
+	// 7. 선택한 메시지 캐시에 저장 (dry-run은 아무것도 남기지 않음)
+	if !request.DryRun {
+		if err := cacheManager.Save(diffHash, selectedMessage); err != nil {
+			// 캐시 저장 실패는 치명적이지 않으므로 계속 진행
+			fmt.Fprintln(r.out, "⚠️ "+r.getMessage("warning_cache_save_failed", lang))
+		}
+	}
+
+	if opts.Print {
+		fmt.Println(strings.TrimRight(selectedMessage, " \t\n"))
+		return nil
+	}
+
+	// 8. 커밋 실행
+	return r.commit(request, provider.Used())
+}
+
+// commit은 요청한 메시지로 커밋하고 기록에 추가합니다.
+// dry-run이면 커밋하지 않고 실행할 git commit 명령어만 출력합니다.
+func (r *RootCommand) commit(request model.CommitRequest, providerName string) error {
+	lang := r.config.Lang
+
+	fmt.Fprintf(r.out, "\n🎯 %s: %s\n", r.getMessage("label_commit_message", lang), request.Message)
+
+	if request.DryRun {
+		fmt.Fprintln(r.out, "\n🧪 "+r.getMessage("dry_run_no_commit", lang))
+		fmt.Fprintln(r.out, git.FormatCommand(git.CommitArgs(request.Message, request.AllowEmpty)))
+		return nil
 	}
 
-	fmt.Println("✅ " + r.getMessage("candidates_generated", lang))
+	fmt.Fprintln(r.out, "\n🚀 "+r.getMessage("executing_commit", lang))
 
-	// 6. 사용자 선택 (재추천 루프)
+	if err := git.Commit(request.Message, request.AllowEmpty); err != nil {
+		return err
+	}
+	r.recordHistory(request.Message, providerName)
+
+	fmt.Fprintln(r.out, "\n✨ "+r.getMessage("commit_complete", lang))
+	return nil
+}
+
+// selectMessage는 선택 화면에서 후보를 고르게 합니다. 재추천을 요청하면 다시 생성합니다.
+func (r *RootCommand) selectMessage(generator *core.Generator, input *model.GeneratorInput, provider *llm.FallbackProvider, messages []string, prevMessage string) (string, error) {
+	lang := r.config.Lang
 	selector := ui.NewSelector(lang)
-	var selectedMessage string
+	selector.SetDiff(input.DiffResult.RawDiff)
+	selector.SetLinter(r.linter(input.LintRules))
 
 	for {
-		selectedMessage, err = selector.Select(messages, prevMessage)
+		selectedMessage, err := selector.Select(messages, prevMessage)
+		if err == nil {
+			return selectedMessage, nil
+		}
 
-		// 에러 타입 확인
-		if err != nil {
-			// 재추천 요청
-			if _, ok := err.(*ui.RegenerateError); ok {
-				fmt.Println("\n🔄 " + r.getMessage("regenerating_messages", lang))
-				messages, err = generator.Generate(diffResult, detail, lang)
-				if err != nil {
-					return fmt.Errorf("%s: %w", r.getMessage("error_generate_failed", lang), err)
-				}
-				fmt.Println("✅ " + r.getMessage("candidates_generated", lang))
-				continue
-			}
+		// 재추천 요청 (이전 후보와 요청을 대화로 전달해 다른 후보를 받음)
+		if regenErr, ok := err.(*ui.RegenerateError); ok {
+			input.Feedback = append(input.Feedback, model.Feedback{Rejected: messages, Hint: regenErr.Hint})
 
-			// 이전 메시지 사용
-			if prevMsgErr, ok := err.(*ui.UsePrevMessageError); ok {
-				selectedMessage = prevMsgErr.Message
-				break
+			fmt.Fprintln(r.out, "\n🔄 "+r.getMessage("regenerating_messages", lang))
+			messages, err = r.generate(generator, input, lang)
+			if err != nil {
+				return "", err
 			}
+			fmt.Fprintln(r.out, "✅ "+r.getMessage("candidates_generated", lang))
+			fmt.Fprintf(r.out, "   %s: %s\n", r.getMessage("label_generated_by", lang), provider.Used())
+			continue
+		}
 
-			// 그 외 에러 (종료 등)
-			return err
+		// 이전 메시지 사용
+		if prevMsgErr, ok := err.(*ui.UsePrevMessageError); ok {
+			return prevMsgErr.Message, nil
+		}
+
+		// 종료
+		var quitErr *ui.QuitError
+		if errors.As(err, &quitErr) {
+			return "", &ExitError{Code: ExitUserQuit, Err: err}
 		}
 
-		// 정상 선택
-		break
+		return "", err
 	}
+}
 
-	// 7. 선택한 메시지 캐시에 저장
-	if err := cacheManager.Save(diffHash, selectedMessage); err != nil {
-		// 캐시 저장 실패는 치명적이지 않으므로 계속 진행
-		fmt.Println("⚠️ " + r.getMessage("warning_cache_save_failed", lang))
+// pickCandidate는 선택 화면 없이 pick번 후보를 반환합니다 (0이면 1번).
+func (r *RootCommand) pickCandidate(messages []string, pick int) (string, error) {
+	if pick == 0 {
+		pick = 1
+	}
+	if pick < 1 || pick > len(messages) {
+		return "", fmt.Errorf(r.getMessage("error_pick_out_of_range", r.config.Lang), pick, len(messages))
 	}
 
-	// 8. 커밋 실행
-	fmt.Printf("\n🎯 %s: %s\n", r.getMessage("label_commit_message", lang), selectedMessage)
-	fmt.Println("\n🚀 " + r.getMessage("executing_commit", lang))
+	fmt.Fprintf(r.out, "\n👉 "+r.getMessage("label_picked_candidate", r.config.Lang)+"\n", pick)
+	return messages[pick-1], nil
+}
 
-	if err := git.Commit(selectedMessage); err != nil {
-		return err
+// candidatesOutput은 --json 출력 형식입니다.
+type candidatesOutput struct {
+	Provider   string   `json:"provider"`   // 후보를 생성한 제공자
+	Type       string   `json:"type"`       // 추천 커밋 타입 (호환성을 깨는 변경이면 "!" 포함)
+	Breaking   bool     `json:"breaking"`   // 호환성을 깨는 변경 포함 여부
+	Scopes     []string `json:"scopes"`     // 추천 scope
+	Candidates []string `json:"candidates"` // 커밋 메시지 후보 (선택 화면의 번호 순서)
+}
+
+// printCandidatesJSON은 후보들을 JSON으로 stdout에 출력합니다.
+func (r *RootCommand) printCandidatesJSON(messages []string, providerName string, diffResult *git.DiffResult) error {
+	output := candidatesOutput{
+		Provider:   providerName,
+		Type:       diffResult.SuggestedType(),
+		Breaking:   diffResult.Breaking,
+		Scopes:     diffResult.Scopes,
+		Candidates: make([]string, len(messages)),
+	}
+	for i, message := range messages {
+		output.Candidates[i] = strings.TrimRight(message, " \t\n")
+	}
+	if output.Scopes == nil {
+		output.Scopes = []string{}
 	}
 
-	fmt.Println("\n✨ " + r.getMessage("commit_complete", lang))
-	return nil
+	encoder := json.NewEncoder(os.Stdout)
+	encoder.SetEscapeHTML(false)
+	encoder.SetIndent("", "  ")
+	return encoder.Encode(output)
 }
 
-// RunWithArgs는 명령줄 인자를 받아 실행합니다.
-func RunWithArgs(args []string) error {
-	// 플래그 정의
-	versionFlag := flag.Bool("v", false, "버전 정보 출력")
-	detailFlag := flag.String("detail", "", "디테일 레벨: low, medium, high")
-	langFlag := flag.String("lang", "", "언어: en, ko")
+// recordHistory는 커밋한 메시지를 기록에 추가합니다.
+// 커밋은 이미 끝났으므로 실패해도 verbose 로그만 남깁니다.
+func (r *RootCommand) recordHistory(message, providerName string) {
+	history, err := cache.NewHistory()
+	if err != nil {
+		r.verbosef("history: %v", err)
+		return
+	}
 
-	// 플래그 파싱
-	flag.CommandLine.Parse(args)
+	repo, _ := git.GetRepoRoot()
+	entry := cache.HistoryEntry{
+		Timestamp: time.Now(),
+		Repo:      repo,
+		Message:   message,
+		Provider:  providerName,
+	}
+	if err := history.Append(entry); err != nil {
+		r.verbosef("history: %v", err)
+	}
+}
 
-	// 버전 출력
-	if *versionFlag {
-		fmt.Printf("git-ai-commit %s\n", version.Version)
+// loadStyle은 저장소의 최근 커밋에서 추론한 작성 스타일을 반환합니다.
+// 저장소별 캐시가 유효하면(같은 HEAD) git log를 다시 읽지 않으며, 실패하면 verbose 로그만 남기고 nil을 반환합니다.
+func (r *RootCommand) loadStyle() *model.StyleProfile {
+	limit := r.config.StyleCommits
+	if limit == 0 {
 		return nil
 	}
 
-	// 디테일 레벨 유효성 검사
-	if *detailFlag != "" {
-		valid := false
-		for _, level := range []string{"low", "medium", "high"} {
-			if *detailFlag == level {
-				valid = true
-				break
-			}
+	repo, err := git.GetRepoRoot()
+	if err != nil {
+		r.verbosef("style: %v", err)
+		return nil
+	}
+	head, err := git.GetHeadCommit()
+	if err != nil {
+		r.verbosef("style: %v", err)
+		return nil
+	}
+
+	styleCache, err := cache.NewStyleCache()
+	if err != nil {
+		r.verbosef("style: %v", err)
+	} else if entry, err := styleCache.Load(repo, head, limit); err != nil {
+		r.verbosef("style: %v", err)
+	} else if entry != nil {
+		r.verbosef("style: cached (%s)", entry.Timestamp.Format(time.RFC3339))
+		return entry.Profile
+	}
+
+	messages, err := git.GetRecentCommitMessages(limit)
+	if err != nil {
+		r.verbosef("style: %v", err)
+		return nil
+	}
+	profile := style.Analyze(messages)
+	if profile == nil {
+		r.verbosef("style: only %d commits, skipping", len(messages))
+	} else {
+		r.verbosef("style: analyzed %d commits (conventional=%t, language=%s, body=%s)", profile.Commits, profile.Conventional, profile.Language, profile.BodyStyle)
+	}
+
+	if styleCache != nil {
+		if err := styleCache.Save(repo, head, limit, profile); err != nil {
+			r.verbosef("style: %v", err)
+		}
+	}
+	return profile
+}
+
+// newProvider는 설정된 제공자 체인으로 FallbackProvider를 생성합니다.
+// 각 제공자는 RetryProvider로 감싸므로, 재시도를 모두 소진한 뒤에 다음 제공자로 넘어갑니다.
+// API 키가 없는 제공자는 건너뛰며, 하나도 생성하지 못하면 에러를 반환합니다.
+func (r *RootCommand) newProvider(chain []config.ProviderSpec, lang string) (*llm.FallbackProvider, error) {
+	if len(chain) == 0 {
+		return nil, errors.New(r.getMessage("error_no_api_key", lang))
+	}
+
+	var entries []llm.FallbackEntry
+	var lastErr error
+	for _, spec := range chain {
+		apiKey, err := r.config.GetAPIKey(spec.Name)
+		if err != nil {
+			lastErr = fmt.Errorf("%s: %w", r.getMessage("error_get_api_key", lang), err)
+			r.verbosef("skipping %s: %v", spec.Name, err)
+			continue
 		}
-		if !valid {
-			return fmt.Errorf("잘못된 디테일 레벨: %s (low, medium, high 중 하나를 입력하세요)", *detailFlag)
+
+		provider, err := llm.NewProvider(spec.Name, llm.Options{
+			APIKey:      apiKey,
+			BaseURL:     r.config.GetBaseURL(spec.Name),
+			Model:       spec.ModelName,
+			Temperature: r.config.Temperature,
+			MaxTokens:   r.config.MaxTokens,
+			JSONMode:    r.jsonMode(chain),
+			Logf:        r.verbosef,
+		})
+		if err != nil {
+			lastErr = fmt.Errorf("%s: %w", r.getMessage("error_create_provider", lang), err)
+			r.verbosef("skipping %s: %v", spec.Name, err)
+			continue
 		}
+
+		// 429/5xx 및 네트워크 에러 재시도
+		entries = append(entries, llm.FallbackEntry{
+			Name: spec.Name,
+			Provider: llm.NewRetryProvider(provider, llm.RetryOptions{
+				MaxRetries: r.config.MaxRetries,
+				Logf:       r.verbosef,
+			}),
+		})
 	}
 
-	// 언어 유효성 검사
-	if *langFlag != "" {
-		valid := false
-		for _, l := range []string{"en", "ko"} {
-			if *langFlag == l {
-				valid = true
-				break
-			}
+	if len(entries) == 0 {
+		return nil, lastErr
+	}
+
+	return llm.NewFallbackProvider(entries, func(format string, args ...any) {
+		fmt.Fprintf(r.out, "⚠️  "+format+"\n", args...)
+	}), nil
+}
+
+// newGeneratorInput은 설정값을 반영한 생성기 입력을 만듭니다.
+func (r *RootCommand) newGeneratorInput(diffResult *git.DiffResult, chain []config.ProviderSpec) *model.GeneratorInput {
+	style := r.loadStyle()
+	return &model.GeneratorInput{
+		DiffResult:    diffResult,
+		Detail:        r.config.Detail,
+		Lang:          r.config.Lang,
+		TokenBudget:   r.tokenBudget(chain),
+		JSONMode:      r.jsonMode(chain),
+		AllowedTypes:  r.config.AllowedTypes,
+		AllowedScopes: r.config.AllowedScopes,
+		LintRules:     r.lintRules(style),
+		Tickets:       r.branchTickets(),
+		TicketMode:    r.config.TicketMode,
+		Style:         style,
+	}
+}
+
+// branchTickets는 현재 브랜치 이름에서 설정한 정규식으로 티켓 번호를 찾습니다.
+// ticket_mode가 off이거나 브랜치를 알 수 없으면 nil을 반환합니다.
+func (r *RootCommand) branchTickets() []string {
+	if r.config.TicketMode == ticket.ModeOff {
+		return nil
+	}
+
+	branch, err := git.GetCurrentBranch()
+	if err != nil {
+		r.verbosef("ticket: %v", err)
+		return nil
+	}
+	// 설정을 로드할 때 검증했으므로 에러가 나지 않음
+	patterns, _ := ticket.Compile(r.config.TicketPatterns)
+	tickets := ticket.Extract(branch, patterns)
+	if len(tickets) > 0 {
+		r.verbosef("ticket: %s from branch %s", strings.Join(tickets, ", "), branch)
+	}
+	return tickets
+}
+
+// newGenerator는 설정된 요약 임계값으로 Generator를 생성합니다.
+func (r *RootCommand) newGenerator(provider llm.Provider) *core.Generator {
+	return core.NewGenerator(provider, core.GeneratorOptions{
+		SummaryFileThreshold: r.config.SummaryFiles,
+		SummaryLineThreshold: r.config.SummaryLines,
+	})
+}
+
+// tokenBudget은 프롬프트 토큰 예산을 반환합니다.
+// 설정값이 없으면 폴백 체인의 모든 제공자에 들어가도록 가장 작은 예산을 사용합니다.
+func (r *RootCommand) tokenBudget(chain []config.ProviderSpec) int {
+	if r.config.TokenBudget > 0 {
+		return r.config.TokenBudget
+	}
+
+	budget := 0
+	for _, spec := range chain {
+		b := llm.PromptBudget(spec.Name, spec.ModelName, r.config.MaxTokens)
+		if budget == 0 || b < budget {
+			budget = b
+		}
+	}
+	return budget
+}
+
+// jsonMode는 폴백 체인에 JSON 형식 응답을 요청할지 반환합니다.
+// 프롬프트는 체인의 모든 제공자에 같이 전달되므로, JSON 모드를 지원하지 않는 제공자(anthropic)가 있으면
+// 모든 제공자에 번호 형식 텍스트를 사용합니다.
+func (r *RootCommand) jsonMode(chain []config.ProviderSpec) bool {
+	if !r.config.JSONMode {
+		return false
+	}
+	for _, spec := range chain {
+		if !llm.SupportsJSONMode(spec.Name) {
+			return false
 		}
-		if !valid {
-			return fmt.Errorf("잘못된 언어 설정: %s (en, ko 중 하나를 입력하세요)", *langFlag)
+	}
+	return true
+}
+
+// commentSetting은 git의 core.commentChar 설정을 반환합니다 ("auto" 포함).
+// 설정을 읽지 못하면 git의 기본값 "#"을 사용합니다.
+func (r *RootCommand) commentSetting() string {
+	setting, err := git.GetCommentChar()
+	if err != nil {
+		r.verbosef("core.commentChar: %v", err)
+		return "#"
+	}
+	return setting
+}
+
+// printBudgetReport는 프롬프트 크기와 예산 초과로 생략된 파일을 출력합니다.
+func (r *RootCommand) printBudgetReport(report *core.BudgetReport, lang string) {
+	r.verbosef("prompt: ~%d tokens (budget %d)", report.Estimated, report.Budget)
+	if !report.Truncated() {
+		return
+	}
+
+	fmt.Fprintf(r.out, "✂️  "+r.getMessage("warning_prompt_truncated", lang)+"\n", report.Budget, report.Included, report.DroppedFiles())
+	for _, summary := range report.Dropped {
+		fmt.Fprintf(r.out, "   - %s/ (%d)\n", summary.Dir, len(summary.Paths))
+	}
+}
+
+// summarize는 큰 diff를 파일 그룹별로 요약해 input.Summaries에 채웁니다.
+// 요약에 실패하면 경고만 출력하고 기존 방식으로 진행하며, 취소나 시간 초과만 에러로 반환합니다.
+func (r *RootCommand) summarize(generator *core.Generator, input *model.GeneratorInput, lang string) error {
+	fmt.Fprintln(r.out, "\n📚 "+r.getMessage("summarizing_diff", lang))
+
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt)
+	defer stop()
+
+	ctx, cancel := context.WithTimeout(ctx, r.config.Timeout)
+	defer cancel()
+
+	summaries, err := generator.Summarize(ctx, input)
+	if err != nil {
+		if ctxErr := r.contextError(ctx, lang); ctxErr != nil {
+			return ctxErr
+		}
+		fmt.Fprintf(r.out, "⚠️  %s: %v\n", r.getMessage("warning_summary_failed", lang), err)
+		return nil
+	}
+
+	input.Summaries = summaries
+	fmt.Fprintf(r.out, "✅ "+r.getMessage("summaries_generated", lang)+"\n", len(summaries))
+	return nil
+}
+
+// generate는 SIGINT와 타임아웃이 연결된 context로 커밋 메시지를 생성합니다.
+// 스트리밍이 켜져 있으면 생성 중인 후보를 실시간으로 출력합니다.
+// 취소되거나 시간이 초과되면 working tree와 캐시를 건드리지 않고 에러를 반환합니다.
+func (r *RootCommand) generate(generator *core.Generator, input *model.GeneratorInput, lang string) ([]string, error) {
+	// 생성 중에만 SIGINT를 가로챔 (선택 화면에서는 기본 동작 유지)
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt)
+	defer stop()
+
+	ctx, cancel := context.WithTimeout(ctx, r.config.Timeout)
+	defer cancel()
+
+	var messages []string
+	var err error
+	if r.config.Stream {
+		printer := ui.NewStreamPrinter(r.out)
+		messages, err = generator.GenerateStream(ctx, input, func(update llm.StreamUpdate) {
+			printer.Update(update.Candidates, update.Partial)
+		})
+		printer.Finish()
+	} else {
+		messages, err = generator.Generate(ctx, input)
+	}
+	if err != nil {
+		if ctxErr := r.contextError(ctx, lang); ctxErr != nil {
+			return nil, ctxErr
 		}
+		return nil, &ExitError{Code: ExitProviderError, Err: fmt.Errorf("%s: %w", r.getMessage("error_generate_failed", lang), err)}
+	}
+
+	return messages, nil
+}
+
+// contextError는 ctx가 시간 초과나 SIGINT로 끝났으면 사용자에게 보여줄 에러를, 아니면 nil을 반환합니다.
+func (r *RootCommand) contextError(ctx context.Context, lang string) error {
+	switch {
+	case errors.Is(ctx.Err(), context.DeadlineExceeded):
+		return &ExitError{Code: ExitProviderError, Err: fmt.Errorf(r.getMessage("error_generate_timeout", lang), r.config.Timeout)}
+	case errors.Is(ctx.Err(), context.Canceled):
+		return &ExitError{Code: ExitUserQuit, Err: errors.New(r.getMessage("error_generate_cancelled", lang))}
+	}
+	return nil
+}
+
+// globalOptions는 모든 명령어에 공통인 명령줄 옵션입니다.
+type globalOptions struct {
+	detail  string
+	lang    string
+	verbose bool
+}
+
+// load는 설정을 로드해 RootCommand를 생성합니다. 명령줄 옵션이 가장 우선합니다.
+func (o *globalOptions) load() (*RootCommand, error) {
+	flags := make(map[string]string)
+	if o.detail != "" {
+		flags["detail"] = o.detail
+	}
+	if o.lang != "" {
+		flags["lang"] = o.lang
 	}
 
-	// 설정 로드
-	cfg, err := config.Load()
+	cfg, err := config.Load(flags)
 	if err != nil {
-		return fmt.Errorf("설정 로드 실패: %w", err)
+		return nil, fmt.Errorf("설정 로드 실패: %w", err)
+	}
+	for _, warning := range cfg.Warnings() {
+		fmt.Fprintln(os.Stderr, "⚠️  "+warning)
+	}
+	return NewRootCommand(cfg, o.verbose), nil
+}
+
+// RunWithArgs는 명령줄 인자를 받아 실행합니다.
+func RunWithArgs(args []string) error {
+	cmd := NewCommand()
+	cmd.SetArgs(args)
+	return cmd.Execute()
+}
+
+// NewCommand는 git-ai-commit 명령어 트리를 생성합니다.
+// 하위 명령어 없이 실행하면 generate와 같습니다.
+func NewCommand() *cobra.Command {
+	opts := &globalOptions{}
+	runOpts := &RunOptions{}
+
+	root := &cobra.Command{
+		Use:   "git-ai-commit",
+		Short: "AI 기반 Conventional Commit 메시지 생성 도구",
+		Long: `staged된 변경 사항을 분석해 Conventional Commit 메시지 후보를 생성하고,
+선택한 메시지로 커밋합니다. git alias로 등록하면 "git ai-commit"으로 실행할 수 있습니다.`,
+		Version:       version.Version,
+		Args:          cobra.NoArgs,
+		SilenceUsage:  true,
+		SilenceErrors: true,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return runGenerate(opts, runOpts)
+		},
+	}
+	addRunFlags(root, runOpts)
+	root.SetVersionTemplate("git-ai-commit {{.Version}}\n")
+
+	flags := root.PersistentFlags()
+	flags.StringVar(&opts.detail, "detail", "", "디테일 레벨: low, medium, high")
+	flags.StringVar(&opts.lang, "lang", "", "언어: en, ko")
+	flags.BoolVar(&opts.verbose, "verbose", false, "상세 로그 출력 (재시도 등)")
+	root.RegisterFlagCompletionFunc("detail", cobra.FixedCompletions([]string{"low", "medium", "high"}, cobra.ShellCompDirectiveNoFileComp))
+	root.RegisterFlagCompletionFunc("lang", cobra.FixedCompletions([]string{"en", "ko"}, cobra.ShellCompDirectiveNoFileComp))
+
+	root.AddCommand(
+		newGenerateCommand(opts),
+		newConfigCommand(opts),
+		newAuthCommand(opts),
+		newCacheCommand(opts),
+		newHistoryCommand(opts),
+		newHookCommand(opts),
+		newLintCommand(opts),
+		newVersionCommand(),
+	)
+	return root
+}
+
+// newGenerateCommand는 커밋 메시지를 생성하고 커밋하는 generate 명령어를 생성합니다.
+func newGenerateCommand(opts *globalOptions) *cobra.Command {
+	runOpts := &RunOptions{}
+	generate := &cobra.Command{
+		Use:   "generate",
+		Short: "커밋 메시지 후보를 생성하고 선택한 메시지로 커밋 (기본 명령어)",
+		Long: `커밋 메시지 후보를 생성하고 선택한 메시지로 커밋합니다.
+--yes, --pick, --print, --json을 지정하면 선택 화면 없이 실행하므로 스크립트와 CI에서 사용할 수 있습니다.
+
+종료 코드: 0 성공, 1 기타 에러, 2 staged된 변경 사항 없음, 3 제공자 에러, 4 사용자 종료`,
+		Args: cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return runGenerate(opts, runOpts)
+		},
 	}
+	addRunFlags(generate, runOpts)
+	return generate
+}
 
-	cmd := NewRootCommand(cfg, *detailFlag, *langFlag)
-	return cmd.Run()
+// addRunFlags는 generate 명령어의 비대화형 실행 옵션을 등록합니다.
+func addRunFlags(cmd *cobra.Command, runOpts *RunOptions) {
+	flags := cmd.Flags()
+	flags.BoolVarP(&runOpts.Yes, "yes", "y", false, "선택 화면 없이 1번 후보(또는 --pick 번호)로 커밋")
+	flags.IntVar(&runOpts.Pick, "pick", 0, "선택 화면 없이 N번 후보 사용 (--print가 없으면 커밋)")
+	flags.BoolVar(&runOpts.Print, "print", false, "커밋하지 않고 선택한 메시지만 stdout으로 출력")
+	flags.BoolVar(&runOpts.JSON, "json", false, "커밋하지 않고 모든 후보를 JSON으로 stdout에 출력")
+	flags.BoolVar(&runOpts.DryRun, "dry-run", false, "커밋하지 않고 메시지와 실행할 git commit 명령어만 출력")
+	flags.BoolVar(&runOpts.AllowEmpty, "allow-empty", false, "staged된 변경 사항이 없어도 커밋 (git commit --allow-empty)")
+	cmd.MarkFlagsMutuallyExclusive("yes", "print", "json")
+	cmd.MarkFlagsMutuallyExclusive("pick", "json")
+	cmd.MarkFlagsMutuallyExclusive("dry-run", "print", "json")
 }
 
-// getLanguage는 언어를 반환합니다.
-// 우선순위: 명령줄 옵션 > 환경 변수 > 기본값
-func (r *RootCommand) getLanguage() string {
-	if r.lang != "" {
-		return r.lang
+// runGenerate는 설정을 로드하고 메인 흐름을 실행합니다.
+func runGenerate(opts *globalOptions, runOpts *RunOptions) error {
+	if runOpts.Pick < 0 {
+		return fmt.Errorf("잘못된 후보 번호: %d", runOpts.Pick)
+	}
+
+	r, err := opts.load()
+	if err != nil {
+		return err
 	}
-	return getEnvWithDefault("AI_COMMIT_LANG", "en")
+	return r.Run(*runOpts)
 }
 
-// getDetailLevel은 디테일 레벨을 반환합니다.
-// 우선순위: 명령줄 옵션 > 환경 변수 > 기본값
-func (r *RootCommand) getDetailLevel() string {
-	if r.detail != "" {
-		return r.detail
+// newVersionCommand는 버전 정보를 출력하는 version 명령어를 생성합니다.
+func newVersionCommand() *cobra.Command {
+	return &cobra.Command{
+		Use:   "version",
+		Short: "버전 정보 출력",
+		Args:  cobra.NoArgs,
+		Run: func(cmd *cobra.Command, args []string) {
+			fmt.Printf("git-ai-commit %s\n", version.Version)
+		},
 	}
-	return getEnvWithDefault("AI_COMMIT_DETAIL", "low")
 }
 
 // getMessage는 언어에 따른 메시지를 반환합니다.
 func (r *RootCommand) getMessage(key, lang string) string {
 	messages := map[string]map[string]string{
+		"error_read_message": {
+			"en": "Failed to read commit message",
+			"ko": "커밋 메시지 읽기 실패",
+		},
+		"error_lint_empty": {
+			"en": "The commit message is empty",
+			"ko": "커밋 메시지가 비어 있습니다",
+		},
+		"lint_passed": {
+			"en": "Commit message follows all rules",
+			"ko": "커밋 메시지가 모든 규칙을 지킵니다",
+		},
+		"lint_failed": {
+			"en": "Commit message has %d problem(s):",
+			"ko": "커밋 메시지에 문제가 %d개 있습니다:",
+		},
 		"error_staged_failed": {
 			"en": "Failed to check staged files",
 			"ko": "staged 파일 확인 실패",
@@ -260,8 +784,8 @@ func (r *RootCommand) getMessage(key, lang string) string {
 			"ko": "diff 분석 실패",
 		},
 		"error_no_api_key": {
-			"en": "No API key available. Please set API key in .env file or environment variables",
-			"ko": "사용 가능한 API 키가 없습니다. .env 파일 또는 환경변수에 API 키를 설정해주세요",
+			"en": "No API key available and no local Ollama server reachable. Please set API key in .env file or environment variables",
+			"ko": "사용 가능한 API 키와 로컬 Ollama 서버가 없습니다. .env 파일 또는 환경변수에 API 키를 설정해주세요",
 		},
 		"error_get_api_key": {
 			"en": "Failed to get API key",
@@ -275,10 +799,30 @@ func (r *RootCommand) getMessage(key, lang string) string {
 			"en": "Failed to generate commit messages",
 			"ko": "커밋 메시지 생성 실패",
 		},
+		"error_generate_timeout": {
+			"en": "Commit message generation timed out after %s. No changes were made",
+			"ko": "커밋 메시지 생성 시간(%s)이 초과되었습니다. 변경 사항은 없습니다",
+		},
+		"error_generate_cancelled": {
+			"en": "Commit message generation cancelled. No changes were made",
+			"ko": "커밋 메시지 생성이 취소되었습니다. 변경 사항은 없습니다",
+		},
+		"warning_prompt_truncated": {
+			"en": "Diff exceeds the token budget (%d): included %d files, summarized %d per directory",
+			"ko": "diff가 토큰 예산(%d)을 초과합니다: 파일 %d개 포함, %d개는 디렉토리별 요약",
+		},
+		"label_tickets": {
+			"en": "Tickets from branch",
+			"ko": "브랜치의 티켓",
+		},
 		"label_recommended_type": {
 			"en": "Recommended commit type",
 			"ko": "추천 커밋 타입",
 		},
+		"label_breaking": {
+			"en": "Breaking changes",
+			"ko": "호환성을 깨는 변경",
+		},
 		"label_recommended_scope": {
 			"en": "Recommended scope",
 			"ko": "추천 scope",
@@ -287,6 +831,10 @@ func (r *RootCommand) getMessage(key, lang string) string {
 			"en": "Using model",
 			"ko": "사용 모델",
 		},
+		"label_generated_by": {
+			"en": "Generated by",
+			"ko": "생성 제공자",
+		},
 		"label_detail_level": {
 			"en": "Detail level",
 			"ko": "디테일 레벨",
@@ -303,6 +851,110 @@ func (r *RootCommand) getMessage(key, lang string) string {
 			"en": "Commit message candidates generated",
 			"ko": "커밋 메시지 후보가 생성되었습니다",
 		},
+		"summarizing_diff": {
+			"en": "Large diff: summarizing file groups in parallel...",
+			"ko": "큰 diff: 파일 그룹별 요약을 병렬로 생성 중...",
+		},
+		"summaries_generated": {
+			"en": "Summarized %d file groups",
+			"ko": "파일 그룹 %d개 요약 완료",
+		},
+		"warning_summary_failed": {
+			"en": "Failed to summarize the diff, using the truncated diff instead",
+			"ko": "diff 요약 실패, 잘라낸 diff를 대신 사용합니다",
+		},
+		"config_title": {
+			"en": "Effective configuration",
+			"ko": "최종 설정",
+		},
+		"label_user_config": {
+			"en": "User config",
+			"ko": "사용자 설정",
+		},
+		"label_repo_config": {
+			"en": "Repo config",
+			"ko": "저장소 설정",
+		},
+		"label_not_found": {
+			"en": "not found",
+			"ko": "없음",
+		},
+		"error_pick_out_of_range": {
+			"en": "Candidate %d does not exist (1-%d)",
+			"ko": "%d번 후보가 없습니다 (1-%d)",
+		},
+		"label_picked_candidate": {
+			"en": "Using candidate %d",
+			"ko": "%d번 후보를 사용합니다",
+		},
+		"cache_empty": {
+			"en": "No cached message",
+			"ko": "캐시된 메시지가 없습니다",
+		},
+		"cache_cleared": {
+			"en": "Cache cleared",
+			"ko": "캐시를 삭제했습니다",
+		},
+		"label_cache_file": {
+			"en": "Cache file",
+			"ko": "캐시 파일",
+		},
+		"label_saved_at": {
+			"en": "Saved at",
+			"ko": "저장 시간",
+		},
+		"label_diff_hash": {
+			"en": "Diff hash",
+			"ko": "diff 해시",
+		},
+		"history_empty": {
+			"en": "No commit history",
+			"ko": "커밋 기록이 없습니다",
+		},
+		"history_cleared": {
+			"en": "History cleared",
+			"ko": "기록을 삭제했습니다",
+		},
+		"prompt_api_key": {
+			"en": "Enter %s API key: ",
+			"ko": "%s API 키를 입력하세요: ",
+		},
+		"error_read_api_key": {
+			"en": "Failed to read API key",
+			"ko": "API 키 입력 실패",
+		},
+		"error_empty_api_key": {
+			"en": "API key is empty",
+			"ko": "API 키가 비어 있습니다",
+		},
+		"error_save_api_key": {
+			"en": "Failed to save API key",
+			"ko": "API 키 저장 실패",
+		},
+		"error_delete_api_key": {
+			"en": "Failed to delete API key",
+			"ko": "API 키 삭제 실패",
+		},
+		"api_key_saved": {
+			"en": "Saved %s API key to %s",
+			"ko": "%s API 키를 저장했습니다: %s",
+		},
+		"api_key_deleted": {
+			"en": "Deleted stored %s API key",
+			"ko": "저장된 %s API 키를 삭제했습니다",
+		},
+		"api_key_not_stored": {
+			"en": "No stored %s API key",
+			"ko": "저장된 %s API 키가 없습니다",
+		},
+		"warning_api_key_overridden": {
+			"en": "The key from %s takes precedence over the stored key",
+			"ko": "%s의 키가 저장된 키보다 우선합니다",
+		},
+		"label_not_set": {
+			"en": "not set",
+			"ko": "설정되지 않음",
+		},
 		"regenerating_messages": {
 			"en": "Regenerating candidates...",
 			"ko": "새로운 후보를 생성 중...",
@@ -311,6 +963,46 @@ func (r *RootCommand) getMessage(key, lang string) string {
 			"en": "Failed to save commit message to cache",
 			"ko": "커밋 메시지 캐시 저장 실패",
 		},
+		"label_empty_commit": {
+			"en": "No staged files: creating an empty commit (--allow-empty)",
+			"ko": "staged된 파일이 없습니다: 빈 커밋을 만듭니다 (--allow-empty)",
+		},
+		"dry_run_no_commit": {
+			"en": "Dry run: no commit was made. The following command would have run:",
+			"ko": "dry-run: 커밋하지 않았습니다. 실행했을 명령어:",
+		},
+		"hook_installed": {
+			"en": "Installed the prepare-commit-msg hook: %s",
+			"ko": "prepare-commit-msg 훅을 설치했습니다: %s",
+		},
+		"hook_uninstalled": {
+			"en": "Uninstalled the prepare-commit-msg hook",
+			"ko": "prepare-commit-msg 훅을 제거했습니다",
+		},
+		"hook_not_installed": {
+			"en": "The git-ai-commit hook is not installed",
+			"ko": "git-ai-commit 훅이 설치되어 있지 않습니다",
+		},
+		"hook_existing_moved": {
+			"en": "Moved the existing hook to %s (it still runs first)",
+			"ko": "기존 훅을 %s로 옮겼습니다 (계속 먼저 실행됨)",
+		},
+		"hook_existing_restored": {
+			"en": "Restored the previous hook: %s",
+			"ko": "기존 훅을 복원했습니다: %s",
+		},
+		"error_hook_backup_exists": {
+			"en": "%s already exists; remove it before installing the hook",
+			"ko": "%s 파일이 이미 있습니다. 삭제한 후 다시 설치해주세요",
+		},
+		"error_hook_executable": {
+			"en": "Failed to find the git-ai-commit executable",
+			"ko": "git-ai-commit 실행 파일 경로 확인 실패",
+		},
+		"hook_other_candidates": {
+			"en": "git-ai-commit: other candidates",
+			"ko": "git-ai-commit: 다른 후보",
+		},
 		"executing_commit": {
 			"en": "Executing commit...",
 			"ko": "커밋을 실행합니다...",
@@ -338,13 +1030,30 @@ func (r *RootCommand) formatFileCount(count int, lang string) string {
 	return fmt.Sprintf("%d file%s staged", count, map[bool]string{true: "s", false: ""}[count > 1])
 }
 
-// getEnvWithDefault는 환경변수를 가져오거나 기본값을 반환합니다.
-func getEnvWithDefault(key, defaultValue string) string {
-	value := os.Getenv(key)
-	if value == "" {
-		return defaultValue
+// verbosef는 verbose 모드일 때만 로그를 출력합니다.
+func (r *RootCommand) verbosef(format string, args ...any) {
+	if !r.verbose {
+		return
+	}
+	fmt.Fprintf(r.out, "   [verbose] "+format+"\n", args...)
+}
+
+// allowedOnly는 allowed에 포함된 값만 남깁니다. allowed가 비어 있으면 그대로 반환합니다.
+func allowedOnly(values, allowed []string) []string {
+	if len(allowed) == 0 {
+		return values
+	}
+
+	var result []string
+	for _, value := range values {
+		for _, a := range allowed {
+			if strings.EqualFold(value, a) {
+				result = append(result, a)
+				break
+			}
+		}
 	}
-	return value
+	return result
 }
 
 // 테스트용 코드
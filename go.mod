@@ -3,3 +3,15 @@ module git-ai-commit
 go 1.25.3
 
 require github.com/sashabaranov/go-openai v1.41.2
+
+require (
+	github.com/spf13/cobra v1.10.1
+	golang.org/x/term v0.40.0
+	gopkg.in/yaml.v3 v3.0.1
+)
+
+require (
+	github.com/inconshreveable/mousetrap v1.1.0 // indirect
+	github.com/spf13/pflag v1.0.9 // indirect
+	golang.org/x/sys v0.41.0 // indirect
+)
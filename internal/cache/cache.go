@@ -64,6 +64,22 @@ func (cm *CacheManager) Save(diffHash string, message string) error {
 
 // Load은 캐시를 로드합니다.
 func (cm *CacheManager) Load(diffHash string) (*Cache, error) {
+	cache, err := cm.Latest()
+	if err != nil || cache == nil {
+		return nil, err
+	}
+
+	// diff hash가 같은지 확인
+	if cache.DiffHash != diffHash {
+		return nil, nil // hash가 다르면 nil 반환
+	}
+
+	return cache, nil
+}
+
+// Latest는 diff hash와 관계없이 마지막으로 저장한 캐시를 반환합니다.
+// 캐시가 없으면 nil을 반환합니다.
+func (cm *CacheManager) Latest() (*Cache, error) {
 	data, err := os.ReadFile(cm.cacheFile)
 	if err != nil {
 		if os.IsNotExist(err) {
@@ -77,14 +93,14 @@ func (cm *CacheManager) Load(diffHash string) (*Cache, error) {
 		return nil, fmt.Errorf("failed to unmarshal cache: %w", err)
 	}
 
-	// diff hash가 같은지 확인
-	if cache.DiffHash != diffHash {
-		return nil, nil // hash가 다르면 nil 반환
-	}
-
 	return &cache, nil
 }
 
+// Path는 캐시 파일 경로를 반환합니다.
+func (cm *CacheManager) Path() string {
+	return cm.cacheFile
+}
+
 // Clear는 캐시를 삭제합니다.
 func (cm *CacheManager) Clear() error {
 	if err := os.Remove(cm.cacheFile); err != nil && !os.IsNotExist(err) {
@@ -0,0 +1,113 @@
+package cache
+
+import (
+	"bufio"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"time"
+)
+
+// HistoryEntry는 커밋한 메시지 기록 하나입니다.
+type HistoryEntry struct {
+	Timestamp time.Time `json:"timestamp"` // 커밋 시간
+	Repo      string    `json:"repo"`      // 저장소 최상위 디렉토리 경로
+	Message   string    `json:"message"`   // 커밋 메시지
+	Provider  string    `json:"provider"`  // 후보를 생성한 제공자
+}
+
+// History는 커밋한 메시지 기록을 관리합니다.
+// 기록은 한 줄에 하나씩 JSON으로 추가합니다 (~/.git-ai-commit/history.jsonl).
+type History struct {
+	historyFile string
+}
+
+// NewHistory는 새로운 History 인스턴스를 생성합니다.
+func NewHistory() (*History, error) {
+	homeDir, err := os.UserHomeDir()
+	if err != nil {
+		return nil, fmt.Errorf("failed to get home directory: %w", err)
+	}
+
+	historyDir := filepath.Join(homeDir, ".git-ai-commit")
+	if err := os.MkdirAll(historyDir, 0755); err != nil {
+		return nil, fmt.Errorf("failed to create cache directory: %w", err)
+	}
+
+	return &History{
+		historyFile: filepath.Join(historyDir, "history.jsonl"),
+	}, nil
+}
+
+// Append는 기록을 추가합니다.
+func (h *History) Append(entry HistoryEntry) error {
+	data, err := json.Marshal(entry)
+	if err != nil {
+		return fmt.Errorf("failed to marshal history entry: %w", err)
+	}
+
+	file, err := os.OpenFile(h.historyFile, os.O_CREATE|os.O_APPEND|os.O_WRONLY, 0644)
+	if err != nil {
+		return fmt.Errorf("failed to open history file: %w", err)
+	}
+	defer file.Close()
+
+	if _, err := file.Write(append(data, '\n')); err != nil {
+		return fmt.Errorf("failed to write history file: %w", err)
+	}
+	return nil
+}
+
+// Recent는 최근 기록을 최신순으로 최대 limit개 반환합니다.
+// repo가 비어 있지 않으면 해당 저장소의 기록만 반환합니다.
+// 손상된 줄은 건너뜁니다.
+func (h *History) Recent(repo string, limit int) ([]HistoryEntry, error) {
+	file, err := os.Open(h.historyFile)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("failed to read history file: %w", err)
+	}
+	defer file.Close()
+
+	var entries []HistoryEntry
+	scanner := bufio.NewScanner(file)
+	scanner.Buffer(make([]byte, 64*1024), 1024*1024)
+	for scanner.Scan() {
+		var entry HistoryEntry
+		if err := json.Unmarshal(scanner.Bytes(), &entry); err != nil {
+			continue
+		}
+		if repo != "" && entry.Repo != repo {
+			continue
+		}
+		entries = append(entries, entry)
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, fmt.Errorf("failed to read history file: %w", err)
+	}
+
+	// 최신순으로 뒤집고 limit개만 반환
+	for i, j := 0, len(entries)-1; i < j; i, j = i+1, j-1 {
+		entries[i], entries[j] = entries[j], entries[i]
+	}
+	if limit > 0 && len(entries) > limit {
+		entries = entries[:limit]
+	}
+	return entries, nil
+}
+
+// Clear는 기록을 모두 삭제합니다.
+func (h *History) Clear() error {
+	if err := os.Remove(h.historyFile); err != nil && !os.IsNotExist(err) {
+		return fmt.Errorf("failed to delete history file: %w", err)
+	}
+	return nil
+}
+
+// Path는 기록 파일 경로를 반환합니다.
+func (h *History) Path() string {
+	return h.historyFile
+}
@@ -0,0 +1,97 @@
+package cache
+
+import (
+	"encoding/json"
+	"fmt"
+	"git-ai-commit/internal/model"
+	"os"
+	"path/filepath"
+	"time"
+)
+
+// StyleTTL은 저장소 커밋 스타일 분석 결과를 다시 사용하는 기간입니다.
+const StyleTTL = 24 * time.Hour
+
+// StyleEntry는 저장소 하나의 커밋 스타일 분석 결과입니다.
+type StyleEntry struct {
+	Timestamp time.Time           `json:"timestamp"` // 분석 시간
+	Repo      string              `json:"repo"`      // 저장소 최상위 디렉토리 경로
+	Head      string              `json:"head"`      // 분석한 시점의 HEAD 커밋 해시
+	Limit     int                 `json:"limit"`     // 분석에 요청한 최근 커밋 수
+	Profile   *model.StyleProfile `json:"profile"`   // 분석 결과
+}
+
+// StyleCache는 저장소별 커밋 스타일 분석 결과를 관리합니다.
+// 저장소마다 파일 하나를 사용합니다 (~/.git-ai-commit/style/<저장소 경로 해시>.json).
+type StyleCache struct {
+	styleDir string
+}
+
+// NewStyleCache는 새로운 StyleCache 인스턴스를 생성합니다.
+func NewStyleCache() (*StyleCache, error) {
+	homeDir, err := os.UserHomeDir()
+	if err != nil {
+		return nil, fmt.Errorf("failed to get home directory: %w", err)
+	}
+
+	styleDir := filepath.Join(homeDir, ".git-ai-commit", "style")
+	if err := os.MkdirAll(styleDir, 0755); err != nil {
+		return nil, fmt.Errorf("failed to create cache directory: %w", err)
+	}
+
+	return &StyleCache{styleDir: styleDir}, nil
+}
+
+// path는 저장소의 캐시 파일 경로를 반환합니다.
+func (sc *StyleCache) path(repo string) string {
+	return filepath.Join(sc.styleDir, CalculateHash(repo)[:16]+".json")
+}
+
+// Load는 저장소의 분석 결과를 반환합니다.
+// 캐시가 없거나, StyleTTL이 지났거나, 그 사이 HEAD가 바뀌었거나(새 커밋, rebase, 저장소 재생성),
+// 요청한 커밋 수가 다르면 nil을 반환합니다.
+func (sc *StyleCache) Load(repo, head string, limit int) (*StyleEntry, error) {
+	data, err := os.ReadFile(sc.path(repo))
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil, nil // 캐시가 없으면 nil 반환 (에러 아님)
+		}
+		return nil, fmt.Errorf("failed to read style cache: %w", err)
+	}
+
+	var entry StyleEntry
+	if err := json.Unmarshal(data, &entry); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal style cache: %w", err)
+	}
+
+	if entry.Repo != repo || entry.Head != head || entry.Limit != limit || entry.Profile == nil || time.Since(entry.Timestamp) > StyleTTL {
+		return nil, nil
+	}
+	return &entry, nil
+}
+
+// Save는 저장소의 분석 결과를 저장합니다.
+// 커밋이 부족해 분석 결과가 없으면(nil) 커밋이 쌓인 뒤 바로 분석하도록 저장하지 않습니다.
+func (sc *StyleCache) Save(repo, head string, limit int, profile *model.StyleProfile) error {
+	if profile == nil {
+		return nil
+	}
+
+	entry := StyleEntry{
+		Timestamp: time.Now(),
+		Repo:      repo,
+		Head:      head,
+		Limit:     limit,
+		Profile:   profile,
+	}
+
+	data, err := json.MarshalIndent(entry, "", "  ")
+	if err != nil {
+		return fmt.Errorf("failed to marshal style cache: %w", err)
+	}
+
+	if err := os.WriteFile(sc.path(repo), data, 0644); err != nil {
+		return fmt.Errorf("failed to write style cache: %w", err)
+	}
+	return nil
+}
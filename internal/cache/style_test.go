@@ -0,0 +1,59 @@
+package cache
+
+import (
+	"git-ai-commit/internal/model"
+	"os"
+	"testing"
+)
+
+func TestStyleCacheInvalidatedByHead(t *testing.T) {
+	sc := &StyleCache{styleDir: t.TempDir()}
+	profile := &model.StyleProfile{Commits: 20, Conventional: true, Language: "en"}
+
+	if err := sc.Save("/repo", "aaa111", 50, profile); err != nil {
+		t.Fatalf("Save: %v", err)
+	}
+
+	tests := []struct {
+		name  string
+		repo  string
+		head  string
+		limit int
+		hit   bool
+	}{
+		{name: "same head", repo: "/repo", head: "aaa111", limit: 50, hit: true},
+		{name: "new commit or rebase", repo: "/repo", head: "bbb222", limit: 50},
+		{name: "repo re-created without commits", repo: "/repo", head: "", limit: 50},
+		{name: "different limit", repo: "/repo", head: "aaa111", limit: 20},
+		{name: "different repo", repo: "/other", head: "aaa111", limit: 50},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			entry, err := sc.Load(tt.repo, tt.head, tt.limit)
+			if err != nil {
+				t.Fatalf("Load: %v", err)
+			}
+			if hit := entry != nil; hit != tt.hit {
+				t.Fatalf("cache hit = %t, want %t", hit, tt.hit)
+			}
+			if tt.hit && entry.Profile.Commits != profile.Commits {
+				t.Errorf("profile = %+v, want %+v", entry.Profile, profile)
+			}
+		})
+	}
+}
+
+func TestStyleCacheSkipsNilProfile(t *testing.T) {
+	sc := &StyleCache{styleDir: t.TempDir()}
+
+	// 커밋이 MinCommits보다 적은 저장소는 저장하지 않으므로 커밋이 쌓이면 바로 다시 분석
+	if err := sc.Save("/repo", "aaa111", 50, nil); err != nil {
+		t.Fatalf("Save: %v", err)
+	}
+	if _, err := os.Stat(sc.path("/repo")); !os.IsNotExist(err) {
+		t.Errorf("a nil profile was written to %s", sc.path("/repo"))
+	}
+	if entry, err := sc.Load("/repo", "aaa111", 50); err != nil || entry != nil {
+		t.Errorf("Load = %+v, %v; want a cache miss", entry, err)
+	}
+}
@@ -1,65 +1,442 @@
 package config
 
 import (
+	"errors"
 	"fmt"
+	"git-ai-commit/internal/credentials"
+	"net/http"
 	"os"
+	"strconv"
 	"strings"
+	"time"
 )
 
+// 로컬 Ollama 서버 기본 주소 및 연결 확인 타임아웃
+const (
+	defaultOllamaHost  = "http://localhost:11434"
+	ollamaProbeTimeout = 500 * time.Millisecond
+)
+
+// LLM 호출 기본값
+const (
+	defaultTimeout    = 60 * time.Second // 생성 한 번에 허용하는 시간 (재시도 포함)
+	defaultMaxRetries = 3                // 일시적 실패 시 재시도 횟수
+)
+
+// 파일 그룹별 요약(map-reduce)을 시작하는 기본 임계값
+const (
+	defaultSummaryFiles = 40   // 생성/잠금 파일을 제외한 파일 수
+	defaultSummaryLines = 1500 // 추가/삭제된 줄 수 합계
+)
+
+// 커밋 스타일 분석에 사용하는 최근 커밋 수 기본값
+const defaultStyleCommits = 100
+
 // Config는 애플리케이션 설정을 나타냅니다.
 type Config struct {
 	// API 키
-	GroqAPIKey string
+	GroqAPIKey      string
+	OpenAIAPIKey    string
+	AnthropicAPIKey string
 
-	// 사용할 LLM 모델 (현재는 groq만 지원)
+	// 사용할 LLM 제공자 (groq, openai, anthropic, ollama)
+	// 쉼표로 여러 개를 지정하면 앞에서부터 순서대로 폴백합니다.
+	// 비어 있으면 GetProviderChain이 API 키가 있는 제공자로 결정합니다.
 	Model string
+
+	// OpenAI 호환 제공자 설정
+	BaseURL     string  // API 엔드포인트 (비어 있으면 제공자 기본값)
+	ModelName   string  // 모델 이름 (비어 있으면 제공자 기본값)
+	Temperature float32 // 샘플링 온도 (0이면 제공자 기본값)
+	MaxTokens   int     // 최대 응답 토큰 수 (0이면 제공자 기본값)
+
+	// Anthropic API 엔드포인트 (테스트용 stand-in 서버 등, 비어 있으면 기본값)
+	AnthropicBaseURL string
+
+	// 로컬 Ollama 서버 주소
+	OllamaHost string
+
+	// LLM 호출 타임아웃 및 재시도 횟수
+	Timeout    time.Duration
+	MaxRetries int
+
+	// 생성 중인 후보를 실시간으로 출력할지 여부 (기본값 true)
+	Stream bool
+
+	// 지원하는 제공자에 JSON 형식 응답을 요청할지 여부 (기본값 true)
+	JSONMode bool
+
+	// 프롬프트 토큰 예산 (0이면 제공자와 모델에 맞춰 자동 결정)
+	TokenBudget int
+
+	// 파일 수나 변경 줄 수가 이 값 이상이면 파일 그룹별 요약을 먼저 생성 (0이면 해당 기준 사용 안 함)
+	SummaryFiles int
+	SummaryLines int
+
+	// 커밋 메시지 검사 규칙: 제목 최대 길이 (0이면 검사 안 함)와 검사하지 않을 규칙
+	MaxSubjectLength int
+	LintDisable      []string
+
+	// 브랜치 이름에서 티켓 번호를 찾는 정규식과 메시지에 넣는 방식 (footer, prefix, off)
+	TicketPatterns []string
+	TicketMode     string
+
+	// 커밋 스타일을 추론할 최근 커밋 수 (0이면 저장소 스타일 학습 사용 안 함)
+	StyleCommits int
+
+	// 메시지 언어 (en, ko)와 디테일 레벨 (low, medium, high)
+	Lang   string
+	Detail string
+
+	// 허용하는 커밋 타입과 scope (비어 있으면 제한 없음)
+	AllowedTypes  []string
+	AllowedScopes []string
+
+	// 설정 항목별 출처 (키 → 출처)
+	sources map[string]Source
+
+	// 설정을 로드하면서 무시한 항목에 대한 경고
+	warnings []string
+
+	// auth login으로 저장한 API 키 저장소 (nil이면 사용 안 함)
+	credentials credentials.Store
+}
+
+// SourceKind는 설정 값을 가져온 계층입니다. 뒤에 오는 계층이 앞의 값을 덮어씁니다.
+type SourceKind int
+
+const (
+	SourceDefault     SourceKind = iota // 기본값
+	SourceCredentials                   // credentials 저장소 (auth login, 다른 곳에 API 키가 없을 때만 사용)
+	SourceRepoFile                      // 저장소 설정 파일 (.git-ai-commit.yaml)
+	SourceUserFile                      // 사용자 설정 파일 (~/.git-ai-commit/config.yaml)
+	SourceGitConfig                     // git config의 ai-commit.* 키 (system < global < local)
+	SourceEnv                           // 환경 변수
+	SourceFlag                          // 명령줄 옵션
+)
+
+// Source는 설정 값 하나의 출처입니다.
+type Source struct {
+	Kind SourceKind
+	Name string // 파일 경로, git config 키, 환경 변수 이름, 옵션 이름 또는 credentials 저장 위치
+}
+
+// String은 출처를 사람이 읽을 수 있는 형식으로 반환합니다.
+func (s Source) String() string {
+	switch s.Kind {
+	case SourceCredentials:
+		return "credentials " + s.Name
+	case SourceUserFile:
+		return "user file " + s.Name
+	case SourceRepoFile:
+		return "repo file " + s.Name
+	case SourceGitConfig:
+		return "git config " + s.Name
+	case SourceEnv:
+		return "env " + s.Name
+	case SourceFlag:
+		return "flag " + s.Name
+	default:
+		return "default"
+	}
+}
+
+// Value는 설정 항목 하나의 최종 값과 출처입니다.
+type Value struct {
+	Key    string
+	Value  string // 비어 있으면 설정되지 않음 (API 키는 일부만 표시)
+	Source Source
 }
 
 // Load는 설정을 로드합니다.
-// 환경 변수에서 API 키를 읽어옵니다.
-func Load() (*Config, error) {
-	cfg := &Config{
-		GroqAPIKey: getEnvWithFallback("AI_COMMIT_GROQ_API_KEY", "GROQ_API_KEY"),
-		Model:      os.Getenv("AI_COMMIT_MODEL"),
+// 우선순위: 기본값 < 저장소 설정 파일 < 사용자 설정 파일 < git config < 환경 변수 < 명령줄 옵션
+// 저장소 설정 파일은 팀이 공유하는 기본값이고, 사용자 설정 파일로 자신의 환경에 맞게 덮어쓸 수 있습니다.
+// API 키가 어디에도 없으면 필요할 때 credentials 저장소에서 찾습니다 (loadCredential 참고).
+// flags는 명령줄에서 지정한 값이며, 키는 설정 파일과 같은 이름입니다 (예: "lang").
+func Load(flags map[string]string) (*Config, error) {
+	cfg := &Config{sources: make(map[string]Source)}
+	if store, err := credentials.DefaultStore(); err == nil {
+		cfg.credentials = store
+	}
+
+	for i := range settings {
+		if err := cfg.apply(&settings[i], settings[i].def, Source{Kind: SourceDefault}); err != nil {
+			return nil, err
+		}
+	}
+
+	if repoPath := RepoConfigPath(); repoPath != "" {
+		if err := cfg.applyFile(repoPath, SourceRepoFile); err != nil {
+			return nil, err
+		}
+	}
+	userPath, err := UserConfigPath()
+	if err != nil {
+		return nil, err
+	}
+	if err := cfg.applyFile(userPath, SourceUserFile); err != nil {
+		return nil, err
+	}
+	for _, scope := range []string{"system", "global", "local"} {
+		if err := cfg.applyGitConfig(scope); err != nil {
+			return nil, err
+		}
 	}
 
-	// 기본 모델은 groq
-	if cfg.Model == "" {
-		cfg.Model = "groq"
+	for i := range settings {
+		for _, name := range settings[i].env {
+			if value := strings.TrimSpace(os.Getenv(name)); value != "" {
+				if err := cfg.apply(&settings[i], value, Source{Kind: SourceEnv, Name: name}); err != nil {
+					return nil, err
+				}
+				break
+			}
+		}
+	}
+
+	for key, value := range flags {
+		s, ok := lookupSetting(key)
+		if !ok {
+			return nil, fmt.Errorf("unknown setting: %s", key)
+		}
+		if err := cfg.apply(s, value, Source{Kind: SourceFlag, Name: "--" + key}); err != nil {
+			return nil, err
+		}
 	}
 
 	return cfg, nil
 }
 
+// apply는 설정 항목 하나에 값을 적용하고 출처를 기록합니다. 빈 값은 무시합니다.
+func (c *Config) apply(s *setting, value string, source Source) error {
+	if value == "" {
+		return nil
+	}
+	if err := s.field.parse(c, value); err != nil {
+		return fmt.Errorf("invalid %s from %s: %s (%v)", s.key, source, value, err)
+	}
+	c.sources[s.key] = source
+	return nil
+}
+
+// Warnings는 설정을 로드하면서 무시한 항목(git config의 알 수 없는 키 등)에 대한 경고를 반환합니다.
+func (c *Config) Warnings() []string {
+	return c.warnings
+}
+
+// Values는 모든 설정 항목의 최종 값과 출처를 정의된 순서대로 반환합니다.
+// API 키는 마지막 4글자만 표시합니다.
+func (c *Config) Values() []Value {
+	values := make([]Value, 0, len(settings))
+	for i := range settings {
+		if provider, ok := strings.CutSuffix(settings[i].key, "_api_key"); ok {
+			c.loadCredential(provider)
+		}
+
+		value := settings[i].field.format(c)
+		if settings[i].secret && value != "" {
+			value = maskSecret(value)
+		}
+		values = append(values, Value{
+			Key:    settings[i].key,
+			Value:  value,
+			Source: c.sources[settings[i].key],
+		})
+	}
+	return values
+}
+
+// loadCredential은 제공자의 API 키가 설정되지 않았으면 credentials 저장소에서 읽어 적용합니다.
+// 복호화는 필요할 때 한 번만 하며, 저장된 키가 없으면 아무것도 하지 않습니다.
+func (c *Config) loadCredential(provider string) error {
+	s, ok := lookupSetting(provider + "_api_key")
+	if !ok || c.credentials == nil || s.field.format(c) != "" {
+		return nil
+	}
+
+	key, err := c.credentials.Get(provider)
+	if errors.Is(err, credentials.ErrNotFound) {
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+	return c.apply(s, key, Source{Kind: SourceCredentials, Name: c.credentials.Location()})
+}
+
+// maskSecret은 API 키를 마지막 4글자만 남기고 가립니다.
+func maskSecret(value string) string {
+	if len(value) <= 8 {
+		return "****"
+	}
+	return "****" + value[len(value)-4:]
+}
+
+// ProviderSpec은 폴백 체인의 한 항목입니다.
+type ProviderSpec struct {
+	Name      string // 제공자 이름 (groq, openai, anthropic, ollama)
+	ModelName string // 모델 이름 (비어 있으면 제공자 기본값)
+}
+
 // GetFirstAvailableModel는 첫 번째 유효한 API 키를 가진 모델을 반환합니다.
+// API 키가 하나도 없으면 로컬 Ollama 서버에 연결 가능한지 확인합니다.
 func (c *Config) GetFirstAvailableModel() string {
+	models := c.GetAvailableModels()
+	if len(models) == 0 {
+		return ""
+	}
+	return models[0]
+}
+
+// GetAvailableModels는 API 키가 설정된 모델들을 우선순위 순서로 반환합니다.
+// API 키가 하나도 없으면 로컬 Ollama 서버에 연결 가능한지 확인합니다.
+func (c *Config) GetAvailableModels() []string {
+	// 저장소를 읽지 못하면 키가 없는 것으로 처리 (GetAPIKey에서 에러를 보고)
+	for _, provider := range credentials.Providers {
+		c.loadCredential(provider)
+	}
+
+	var models []string
 	if c.GroqAPIKey != "" {
-		return "groq"
+		models = append(models, "groq")
 	}
-	return ""
+	if c.OpenAIAPIKey != "" || c.BaseURL != "" {
+		models = append(models, "openai")
+	}
+	if c.AnthropicAPIKey != "" {
+		models = append(models, "anthropic")
+	}
+	if len(models) == 0 && isReachable(c.OllamaHost+"/api/tags") {
+		models = append(models, "ollama")
+	}
+	return models
+}
+
+// GetProviderChain은 순서대로 시도할 제공자 목록을 반환합니다.
+// Model은 "groq,openai:gpt-4o-mini,ollama"처럼 쉼표로 구분하며,
+// ":" 뒤에 항목별 모델 이름을 지정할 수 있습니다.
+// Model이 비어 있으면 API 키가 설정된 모든 제공자를 사용합니다.
+// ModelName은 항목별 모델 이름이 없는 첫 번째 제공자에만 적용됩니다.
+func (c *Config) GetProviderChain() []ProviderSpec {
+	var names []string
+	if strings.TrimSpace(c.Model) == "" {
+		names = c.GetAvailableModels()
+	} else {
+		names = strings.Split(c.Model, ",")
+	}
+
+	var chain []ProviderSpec
+	for _, entry := range names {
+		entry = strings.TrimSpace(entry)
+		if entry == "" {
+			continue
+		}
+
+		// ollama 모델 이름(llama3.2:3b)에도 ":"가 있으므로 첫 번째 ":"만 구분자로 사용
+		spec := ProviderSpec{Name: strings.ToLower(entry)}
+		if name, modelName, ok := strings.Cut(entry, ":"); ok {
+			spec = ProviderSpec{Name: strings.ToLower(strings.TrimSpace(name)), ModelName: strings.TrimSpace(modelName)}
+		}
+		chain = append(chain, spec)
+	}
+
+	if len(chain) > 0 && chain[0].ModelName == "" {
+		chain[0].ModelName = c.ModelName
+	}
+
+	return chain
 }
 
 // GetAPIKey는 지정된 모델의 API 키를 반환합니다.
+// 환경 변수와 설정 파일에 없으면 credentials 저장소(auth login)에서 찾습니다.
 func (c *Config) GetAPIKey(model string) (string, error) {
 	model = strings.ToLower(model)
+	if provider, err := credentials.NormalizeProvider(model); err == nil {
+		if err := c.loadCredential(provider); err != nil {
+			return "", err
+		}
+	}
 
 	switch model {
 	case "groq":
 		if c.GroqAPIKey == "" {
-			return "", fmt.Errorf("Groq API key not found. Please set AI_COMMIT_GROQ_API_KEY environment variable")
+			return "", fmt.Errorf("Groq API key not found. Please set AI_COMMIT_GROQ_API_KEY environment variable or run 'git ai-commit auth login groq'")
 		}
 		return c.GroqAPIKey, nil
+	case "openai":
+		// 사내 게이트웨이처럼 인증이 필요 없는 엔드포인트는 키 없이 허용
+		if c.OpenAIAPIKey == "" && c.BaseURL == "" {
+			return "", fmt.Errorf("OpenAI API key not found. Please set AI_COMMIT_OPENAI_API_KEY environment variable or run 'git ai-commit auth login openai'")
+		}
+		return c.OpenAIAPIKey, nil
+	case "anthropic", "claude":
+		if c.AnthropicAPIKey == "" {
+			return "", fmt.Errorf("Anthropic API key not found. Please set AI_COMMIT_ANTHROPIC_API_KEY environment variable or run 'git ai-commit auth login anthropic'")
+		}
+		return c.AnthropicAPIKey, nil
+	case "ollama":
+		// 로컬 서버는 API 키가 필요 없음
+		return "", nil
 	default:
-		return "", fmt.Errorf("unknown model: %s (only 'groq' is supported)", model)
+		return "", fmt.Errorf("unknown model: %s (supported: groq, openai, anthropic, ollama)", model)
 	}
 }
 
-// getEnvWithFallback은 여러 환경 변수 이름 중 첫 번째로 존재하는 값을 반환합니다.
-func getEnvWithFallback(keys ...string) string {
-	for _, key := range keys {
-		if value := os.Getenv(key); value != "" {
-			return value
+// GetBaseURL은 지정된 모델이 사용할 API 엔드포인트를 반환합니다.
+// 비어 있으면 제공자 기본값을 사용합니다.
+func (c *Config) GetBaseURL(model string) string {
+	switch strings.ToLower(model) {
+	case "openai":
+		return c.BaseURL
+	case "anthropic", "claude":
+		return c.AnthropicBaseURL
+	case "ollama":
+		return c.OllamaHost
+	default:
+		return ""
+	}
+}
+
+// normalizeHost는 OLLAMA_HOST 형식(예: "127.0.0.1:11434")을 URL로 변환합니다.
+func normalizeHost(host string) string {
+	host = strings.TrimRight(strings.TrimSpace(host), "/")
+	if host == "" {
+		return defaultOllamaHost
+	}
+	if !strings.HasPrefix(host, "http://") && !strings.HasPrefix(host, "https://") {
+		host = "http://" + host
+	}
+	return host
+}
+
+// isReachable은 주어진 URL이 짧은 타임아웃 안에 응답하는지 확인합니다.
+func isReachable(url string) bool {
+	client := &http.Client{Timeout: ollamaProbeTimeout}
+
+	resp, err := client.Get(url)
+	if err != nil {
+		return false
+	}
+	defer resp.Body.Close()
+
+	return resp.StatusCode == http.StatusOK
+}
+
+// parseDuration은 "90s", "2m" 같은 duration 또는 초 단위 정수를 파싱합니다.
+func parseDuration(value string) (time.Duration, error) {
+	if seconds, err := strconv.Atoi(value); err == nil {
+		if seconds <= 0 {
+			return 0, fmt.Errorf("duration must be positive")
 		}
+		return time.Duration(seconds) * time.Second, nil
+	}
+
+	duration, err := time.ParseDuration(value)
+	if err != nil {
+		return 0, err
+	}
+	if duration <= 0 {
+		return 0, fmt.Errorf("duration must be positive")
 	}
-	return ""
+	return duration, nil
 }
@@ -0,0 +1,92 @@
+package config
+
+import (
+	"os"
+	"os/exec"
+	"path/filepath"
+	"testing"
+)
+
+// setupConfigDirs는 빈 HOME과 git 저장소를 만들고 작업 디렉토리를 저장소로 옮깁니다.
+// 사용자 설정 파일 경로와 저장소 설정 파일 경로를 반환합니다.
+func setupConfigDirs(t *testing.T) (userPath, repoPath string) {
+	t.Helper()
+
+	home := t.TempDir()
+	repo := t.TempDir()
+	t.Setenv("HOME", home)
+	t.Setenv("GIT_CONFIG_NOSYSTEM", "1")
+	for _, s := range settings {
+		for _, name := range s.env {
+			t.Setenv(name, "")
+		}
+	}
+
+	if output, err := exec.Command("git", "init", "-q", repo).CombinedOutput(); err != nil {
+		t.Fatalf("git init: %v: %s", err, output)
+	}
+	t.Chdir(repo)
+
+	userPath = filepath.Join(home, userConfigDir, userConfigFile)
+	if err := os.MkdirAll(filepath.Dir(userPath), 0700); err != nil {
+		t.Fatal(err)
+	}
+	return userPath, filepath.Join(repo, repoConfigFile)
+}
+
+func writeConfigFile(t *testing.T, path, content string) {
+	t.Helper()
+	if err := os.WriteFile(path, []byte(content), 0600); err != nil {
+		t.Fatal(err)
+	}
+}
+
+// sourceOf는 설정 항목의 최종 출처를 반환합니다.
+func sourceOf(t *testing.T, cfg *Config, key string) Source {
+	t.Helper()
+	for _, value := range cfg.Values() {
+		if value.Key == key {
+			return value.Source
+		}
+	}
+	t.Fatalf("no value for %s", key)
+	return Source{}
+}
+
+func TestLoadUserFileOverridesRepoFile(t *testing.T) {
+	userPath, repoPath := setupConfigDirs(t)
+	writeConfigFile(t, repoPath, "detail: high\nlang: ko\n")
+	writeConfigFile(t, userPath, "detail: medium\n")
+
+	cfg, err := Load(nil)
+	if err != nil {
+		t.Fatalf("Load: %v", err)
+	}
+
+	// 같은 키는 사용자 설정 파일이, 사용자 설정에 없는 키는 저장소 설정 파일이 정함
+	if cfg.Detail != "medium" || sourceOf(t, cfg, "detail").Kind != SourceUserFile {
+		t.Errorf("detail = %q from %s, want medium from the user file", cfg.Detail, sourceOf(t, cfg, "detail"))
+	}
+	if cfg.Lang != "ko" || sourceOf(t, cfg, "lang").Kind != SourceRepoFile {
+		t.Errorf("lang = %q from %s, want ko from the repo file", cfg.Lang, sourceOf(t, cfg, "lang"))
+	}
+}
+
+func TestLoadEnvAndFlagsOverrideFiles(t *testing.T) {
+	userPath, repoPath := setupConfigDirs(t)
+	writeConfigFile(t, repoPath, "detail: high\nlang: ko\n")
+	writeConfigFile(t, userPath, "detail: medium\nlang: ko\n")
+	t.Setenv("AI_COMMIT_DETAIL", "low")
+
+	cfg, err := Load(map[string]string{"lang": "en"})
+	if err != nil {
+		t.Fatalf("Load: %v", err)
+	}
+
+	if cfg.Detail != "low" || sourceOf(t, cfg, "detail").Kind != SourceEnv {
+		t.Errorf("detail = %q from %s, want low from the environment", cfg.Detail, sourceOf(t, cfg, "detail"))
+	}
+	if cfg.Lang != "en" || sourceOf(t, cfg, "lang").Kind != SourceFlag {
+		t.Errorf("lang = %q from %s, want en from the flag", cfg.Lang, sourceOf(t, cfg, "lang"))
+	}
+}
@@ -0,0 +1,204 @@
+package config
+
+import (
+	"errors"
+	"fmt"
+	"git-ai-commit/internal/git"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+)
+
+// 설정 파일 이름과 git config 섹션
+const (
+	repoConfigFile = ".git-ai-commit.yaml" // 저장소 루트의 프로젝트 설정 (커밋해서 공유)
+	userConfigDir  = ".git-ai-commit"      // 홈 디렉토리 아래 사용자 설정 디렉토리
+	userConfigFile = "config.yaml"
+
+	gitConfigSection = "ai-commit" // git config 키 접두사 (예: ai-commit.lang)
+)
+
+// UserConfigPath는 사용자 설정 파일 경로(~/.git-ai-commit/config.yaml)를 반환합니다.
+func UserConfigPath() (string, error) {
+	homeDir, err := os.UserHomeDir()
+	if err != nil {
+		return "", fmt.Errorf("failed to get home directory: %w", err)
+	}
+	return filepath.Join(homeDir, userConfigDir, userConfigFile), nil
+}
+
+// RepoConfigPath는 저장소 설정 파일 경로(<저장소 루트>/.git-ai-commit.yaml)를 반환합니다.
+// git 저장소 밖이면 빈 문자열을 반환합니다.
+func RepoConfigPath() string {
+	root, err := git.GetRepoRoot()
+	if err != nil {
+		return ""
+	}
+	return filepath.Join(root, repoConfigFile)
+}
+
+// applyFile은 설정 파일의 값을 적용합니다. 파일이 없으면 아무것도 하지 않습니다.
+// 저장소 설정 파일에는 API 키를 둘 수 없습니다 (저장소에 커밋되므로).
+func (c *Config) applyFile(path string, kind SourceKind) error {
+	values, err := readFile(path)
+	if err != nil {
+		return err
+	}
+
+	// 에러 메시지가 항상 같도록 키 순서대로 적용
+	keys := make([]string, 0, len(values))
+	for key := range values {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+
+	source := Source{Kind: kind, Name: path}
+	for _, key := range keys {
+		s, ok := lookupSetting(key)
+		if !ok {
+			return fmt.Errorf("unknown key %q in %s", key, path)
+		}
+		if s.secret && kind == SourceRepoFile {
+			return fmt.Errorf("%s must not be set in %s (it is committed with the repository; use an environment variable or %s instead)",
+				s.key, path, filepath.Join("~", userConfigDir, userConfigFile))
+		}
+		value := values[key]
+		if value.items != nil {
+			err = c.applyList(s, value.items, source)
+		} else {
+			err = c.apply(s, value.text, source)
+		}
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// applyList는 설정 파일의 YAML 목록을 적용합니다. 빈 목록은 무시합니다.
+// 목록 항목이 아닌 설정(model의 폴백 체인 등)은 쉼표로 이어 붙여 문자열 값으로 적용합니다.
+func (c *Config) applyList(s *setting, items []string, source Source) error {
+	if s.field.parseList == nil {
+		for _, item := range items {
+			if strings.Contains(item, ",") {
+				return fmt.Errorf("invalid %s from %s: list item %q must not contain ','", s.key, source, item)
+			}
+		}
+		return c.apply(s, strings.Join(items, ","), source)
+	}
+
+	if len(items) == 0 {
+		return nil
+	}
+	if err := s.field.parseList(c, items); err != nil {
+		return fmt.Errorf("invalid %s from %s: %s (%v)", s.key, source, strings.Join(items, ", "), err)
+	}
+	c.sources[s.key] = source
+	return nil
+}
+
+// applyGitConfig는 지정한 범위(system, global, local)의 git config에서 ai-commit.* 키를 적용합니다.
+// git config 키에는 '_'를 쓸 수 없으므로 "ai-commit.max-tokens"처럼 '-'로 구분합니다.
+// git이 설치되지 않았거나 저장소 밖이라 local 범위가 없으면 건너뛰고, 그 외 git 실패는 에러로 반환합니다.
+// git config는 다른 사람이 관리하는 system 범위 등에도 있으므로 알 수 없는 키는 경고만 남기고 무시합니다.
+func (c *Config) applyGitConfig(scope string) error {
+	if scope == "local" {
+		if _, err := git.GetRepoRoot(); err != nil {
+			return nil
+		}
+	}
+	values, err := git.GetConfigSection(scope, gitConfigSection)
+	if errors.Is(err, exec.ErrNotFound) {
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+
+	keys := make([]string, 0, len(values))
+	for key := range values {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+
+	for _, key := range keys {
+		name := gitConfigSection + "." + key
+		s, ok := lookupSetting(key)
+		if !ok {
+			c.warnings = append(c.warnings, fmt.Sprintf("ignoring unknown key %s in git config --%s", name, scope))
+			continue
+		}
+		source := Source{Kind: SourceGitConfig, Name: fmt.Sprintf("--%s %s", scope, name)}
+		if err := c.apply(s, strings.TrimSpace(values[key]), source); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// fileValue는 설정 파일의 값 하나입니다. YAML 목록이면 items에, 아니면 text에 담습니다.
+type fileValue struct {
+	text  string
+	items []string // nil이 아니면 YAML 목록
+}
+
+// readFile은 YAML 설정 파일을 읽어 키별 값으로 반환합니다.
+// 목록은 항목별로 그대로 두고, 값이 비어 있는 키는 무시합니다.
+func readFile(path string) (map[string]fileValue, error) {
+	data, err := os.ReadFile(path)
+	if errors.Is(err, os.ErrNotExist) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to read %s: %w", path, err)
+	}
+
+	var raw map[string]any
+	if err := yaml.Unmarshal(data, &raw); err != nil {
+		return nil, fmt.Errorf("failed to parse %s: %w", path, err)
+	}
+
+	values := make(map[string]fileValue, len(raw))
+	for key, value := range raw {
+		list, ok := value.([]any)
+		if !ok {
+			text, err := scalarString(value)
+			if err != nil {
+				return nil, fmt.Errorf("invalid %s in %s: %w", key, path, err)
+			}
+			values[key] = fileValue{text: text}
+			continue
+		}
+
+		items := make([]string, 0, len(list))
+		for _, item := range list {
+			text, err := scalarString(item)
+			if err != nil {
+				return nil, fmt.Errorf("invalid %s in %s: %w", key, path, err)
+			}
+			items = append(items, text)
+		}
+		values[key] = fileValue{items: items}
+	}
+	return values, nil
+}
+
+// scalarString은 YAML 값(문자열, 숫자, 불리언)을 문자열로 변환합니다.
+func scalarString(value any) (string, error) {
+	switch v := value.(type) {
+	case nil:
+		return "", nil
+	case string:
+		return strings.TrimSpace(v), nil
+	case []any:
+		return "", errors.New("nested lists are not supported")
+	case map[string]any:
+		return "", errors.New("nested mappings are not supported")
+	default:
+		return fmt.Sprint(v), nil
+	}
+}
@@ -0,0 +1,249 @@
+package config
+
+import (
+	"errors"
+	"fmt"
+	"git-ai-commit/internal/lint"
+	"git-ai-commit/internal/ticket"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// field는 설정 항목 하나를 Config의 필드와 연결합니다.
+// 설정 파일, 환경 변수, 명령줄 옵션의 값은 문자열로 전달되고, 설정 파일의 YAML 목록만 항목별로 전달됩니다.
+type field struct {
+	parse     func(c *Config, value string) error   // 값을 파싱해 Config에 기록
+	parseList func(c *Config, items []string) error // 설정 파일의 YAML 목록을 항목 그대로 기록 (목록 항목만)
+	format    func(c *Config) string                // Config의 현재 값을 문자열로 반환
+}
+
+// setting은 설정 항목 하나의 정의입니다.
+// key는 설정 파일에서 쓰는 이름이며, 환경 변수 이름에서 AI_COMMIT_ 접두사를 뺀 소문자입니다.
+type setting struct {
+	key    string
+	alias  string   // 같은 항목의 다른 이름 (비어 있으면 없음)
+	env    []string // 환경 변수 이름 (앞에서부터 확인)
+	def    string   // 기본값 (비어 있으면 없음)
+	secret bool     // API 키처럼 저장소 설정 파일에 둘 수 없고 출력 시 가리는 값
+	field  field
+}
+
+// settings는 지원하는 모든 설정 항목입니다. config 명령어는 이 순서로 출력합니다.
+var settings = []setting{
+	{key: "model", alias: "provider", env: []string{"AI_COMMIT_MODEL"}, field: stringField(func(c *Config) *string { return &c.Model })},
+	{key: "model_name", env: []string{"AI_COMMIT_MODEL_NAME"}, field: stringField(func(c *Config) *string { return &c.ModelName })},
+	{key: "lang", env: []string{"AI_COMMIT_LANG"}, def: "en", field: choiceField(func(c *Config) *string { return &c.Lang }, "en", "ko")},
+	{key: "detail", env: []string{"AI_COMMIT_DETAIL"}, def: "low", field: choiceField(func(c *Config) *string { return &c.Detail }, "low", "medium", "high")},
+	{key: "types", env: []string{"AI_COMMIT_TYPES"}, field: listField(func(c *Config) *[]string { return &c.AllowedTypes })},
+	{key: "scopes", env: []string{"AI_COMMIT_SCOPES"}, field: listField(func(c *Config) *[]string { return &c.AllowedScopes })},
+	{key: "groq_api_key", env: []string{"AI_COMMIT_GROQ_API_KEY", "GROQ_API_KEY"}, secret: true, field: stringField(func(c *Config) *string { return &c.GroqAPIKey })},
+	{key: "openai_api_key", env: []string{"AI_COMMIT_OPENAI_API_KEY", "OPENAI_API_KEY"}, secret: true, field: stringField(func(c *Config) *string { return &c.OpenAIAPIKey })},
+	{key: "anthropic_api_key", env: []string{"AI_COMMIT_ANTHROPIC_API_KEY", "ANTHROPIC_API_KEY"}, secret: true, field: stringField(func(c *Config) *string { return &c.AnthropicAPIKey })},
+	{key: "base_url", env: []string{"AI_COMMIT_BASE_URL"}, field: stringField(func(c *Config) *string { return &c.BaseURL })},
+	{key: "anthropic_base_url", env: []string{"AI_COMMIT_ANTHROPIC_BASE_URL"}, field: stringField(func(c *Config) *string { return &c.AnthropicBaseURL })},
+	{key: "ollama_host", env: []string{"AI_COMMIT_OLLAMA_HOST", "OLLAMA_HOST"}, def: defaultOllamaHost, field: hostField(func(c *Config) *string { return &c.OllamaHost })},
+	{key: "temperature", env: []string{"AI_COMMIT_TEMPERATURE"}, field: floatField(func(c *Config) *float32 { return &c.Temperature })},
+	{key: "max_tokens", env: []string{"AI_COMMIT_MAX_TOKENS"}, field: intField(func(c *Config) *int { return &c.MaxTokens }, 1)},
+	{key: "timeout", env: []string{"AI_COMMIT_TIMEOUT"}, def: defaultTimeout.String(), field: durationField(func(c *Config) *time.Duration { return &c.Timeout })},
+	{key: "max_retries", env: []string{"AI_COMMIT_MAX_RETRIES"}, def: strconv.Itoa(defaultMaxRetries), field: intField(func(c *Config) *int { return &c.MaxRetries }, 0)},
+	{key: "stream", env: []string{"AI_COMMIT_STREAM"}, def: "true", field: boolField(func(c *Config) *bool { return &c.Stream })},
+	{key: "json_mode", env: []string{"AI_COMMIT_JSON_MODE"}, def: "true", field: boolField(func(c *Config) *bool { return &c.JSONMode })},
+	{key: "token_budget", env: []string{"AI_COMMIT_TOKEN_BUDGET"}, field: intField(func(c *Config) *int { return &c.TokenBudget }, 1)},
+	{key: "summary_files", env: []string{"AI_COMMIT_SUMMARY_FILES"}, def: strconv.Itoa(defaultSummaryFiles), field: intField(func(c *Config) *int { return &c.SummaryFiles }, 0)},
+	{key: "summary_lines", env: []string{"AI_COMMIT_SUMMARY_LINES"}, def: strconv.Itoa(defaultSummaryLines), field: intField(func(c *Config) *int { return &c.SummaryLines }, 0)},
+	{key: "style_commits", env: []string{"AI_COMMIT_STYLE_COMMITS"}, def: strconv.Itoa(defaultStyleCommits), field: intField(func(c *Config) *int { return &c.StyleCommits }, 0)},
+	{key: "max_subject_length", env: []string{"AI_COMMIT_MAX_SUBJECT_LENGTH"}, def: strconv.Itoa(lint.DefaultMaxSubjectLength), field: intField(func(c *Config) *int { return &c.MaxSubjectLength }, 0)},
+	{key: "lint_disable", env: []string{"AI_COMMIT_LINT_DISABLE"}, field: choiceListField(func(c *Config) *[]string { return &c.LintDisable }, lint.RuleNames...)},
+	{key: "ticket_patterns", env: []string{"AI_COMMIT_TICKET_PATTERNS"}, def: ticket.DefaultPattern, field: patternListField(func(c *Config) *[]string { return &c.TicketPatterns })},
+	{key: "ticket_mode", env: []string{"AI_COMMIT_TICKET_MODE"}, def: ticket.ModeFooter, field: choiceField(func(c *Config) *string { return &c.TicketMode }, ticket.Modes...)},
+}
+
+// lookupSetting은 키에 해당하는 설정 항목을 찾습니다.
+// 별칭(provider → model), "max-tokens"처럼 '-'로 구분한 키와 대소문자 차이도 허용합니다.
+func lookupSetting(key string) (*setting, bool) {
+	key = normalizeKey(key)
+	for i := range settings {
+		if settings[i].key == key || (settings[i].alias != "" && settings[i].alias == key) {
+			return &settings[i], true
+		}
+	}
+	return nil, false
+}
+
+// normalizeKey는 설정 키를 소문자와 '_' 구분 형식으로 변환합니다.
+func normalizeKey(key string) string {
+	return strings.ReplaceAll(strings.ToLower(strings.TrimSpace(key)), "-", "_")
+}
+
+// stringField는 문자열 설정 항목입니다.
+func stringField(ptr func(*Config) *string) field {
+	return field{
+		parse: func(c *Config, value string) error {
+			*ptr(c) = value
+			return nil
+		},
+		format: func(c *Config) string { return *ptr(c) },
+	}
+}
+
+// hostField는 Ollama 서버 주소 설정 항목입니다. "127.0.0.1:11434" 형식도 URL로 변환합니다.
+func hostField(ptr func(*Config) *string) field {
+	return field{
+		parse: func(c *Config, value string) error {
+			*ptr(c) = normalizeHost(value)
+			return nil
+		},
+		format: func(c *Config) string { return *ptr(c) },
+	}
+}
+
+// choiceField는 정해진 값 중 하나만 허용하는 설정 항목입니다.
+func choiceField(ptr func(*Config) *string, choices ...string) field {
+	return field{
+		parse: func(c *Config, value string) error {
+			value = strings.ToLower(value)
+			for _, choice := range choices {
+				if value == choice {
+					*ptr(c) = value
+					return nil
+				}
+			}
+			return fmt.Errorf("supported: %s", strings.Join(choices, ", "))
+		},
+		format: func(c *Config) string { return *ptr(c) },
+	}
+}
+
+// listField는 쉼표로 구분한 목록 설정 항목입니다.
+// 설정 파일의 YAML 목록은 나누지 않고 항목 그대로 사용합니다.
+func listField(ptr func(*Config) *[]string) field {
+	return itemsField(ptr, splitComma, ",", nil)
+}
+
+// choiceListField는 정해진 값만 허용하는 쉼표 구분 목록 설정 항목입니다.
+func choiceListField(ptr func(*Config) *[]string, choices ...string) field {
+	return itemsField(ptr, splitComma, ",", func(items []string) error {
+		for i, item := range items {
+			items[i] = strings.ToLower(item)
+			if !slices.Contains(choices, items[i]) {
+				return fmt.Errorf("supported: %s", strings.Join(choices, ", "))
+			}
+		}
+		return nil
+	})
+}
+
+// patternListField는 정규식 목록 설정 항목입니다. 잘못된 정규식은 설정을 로드할 때 에러로 처리합니다.
+// 정규식에는 쉼표({2,5})가 들어갈 수 있으므로 환경 변수, git config, 옵션 값은 공백(줄바꿈 포함)으로 구분합니다.
+// 정규식의 공백 문자는 \s나 \x20으로 씁니다.
+func patternListField(ptr func(*Config) *[]string) field {
+	return itemsField(ptr, strings.Fields, " ", func(items []string) error {
+		_, err := ticket.Compile(items)
+		return err
+	})
+}
+
+// itemsField는 목록 설정 항목입니다. 문자열 값은 split으로 나누고, 설정 파일의 YAML 목록은 항목 그대로 사용합니다.
+// 항목의 앞뒤 공백을 지우고 빈 항목은 버린 뒤, check가 있으면 검사합니다 (check는 항목을 바꿀 수 있음).
+func itemsField(ptr func(*Config) *[]string, split func(string) []string, sep string, check func(items []string) error) field {
+	parseList := func(c *Config, values []string) error {
+		var items []string
+		for _, item := range values {
+			if item = strings.TrimSpace(item); item != "" {
+				items = append(items, item)
+			}
+		}
+		if check != nil {
+			if err := check(items); err != nil {
+				return err
+			}
+		}
+		*ptr(c) = items
+		return nil
+	}
+	return field{
+		parse:     func(c *Config, value string) error { return parseList(c, split(value)) },
+		parseList: parseList,
+		format:    func(c *Config) string { return strings.Join(*ptr(c), sep) },
+	}
+}
+
+// splitComma는 쉼표로 구분한 목록을 나눕니다.
+func splitComma(value string) []string {
+	return strings.Split(value, ",")
+}
+
+// intField는 min 이상의 정수 설정 항목입니다.
+func intField(ptr func(*Config) *int, min int) field {
+	return field{
+		parse: func(c *Config, value string) error {
+			n, err := strconv.Atoi(value)
+			if err != nil {
+				return errors.New("not an integer")
+			}
+			if n < min {
+				return fmt.Errorf("must be at least %d", min)
+			}
+			*ptr(c) = n
+			return nil
+		},
+		format: func(c *Config) string {
+			if *ptr(c) == 0 && min > 0 {
+				return ""
+			}
+			return strconv.Itoa(*ptr(c))
+		},
+	}
+}
+
+// floatField는 실수 설정 항목입니다. 0은 제공자 기본값을 의미합니다.
+func floatField(ptr func(*Config) *float32) field {
+	return field{
+		parse: func(c *Config, value string) error {
+			f, err := strconv.ParseFloat(value, 32)
+			if err != nil {
+				return errors.New("not a number")
+			}
+			*ptr(c) = float32(f)
+			return nil
+		},
+		format: func(c *Config) string {
+			if *ptr(c) == 0 {
+				return ""
+			}
+			return strconv.FormatFloat(float64(*ptr(c)), 'g', -1, 32)
+		},
+	}
+}
+
+// boolField는 true/false 설정 항목입니다.
+func boolField(ptr func(*Config) *bool) field {
+	return field{
+		parse: func(c *Config, value string) error {
+			b, err := strconv.ParseBool(value)
+			if err != nil {
+				return errors.New("not a boolean")
+			}
+			*ptr(c) = b
+			return nil
+		},
+		format: func(c *Config) string { return strconv.FormatBool(*ptr(c)) },
+	}
+}
+
+// durationField는 "90s", "2m" 같은 duration 또는 초 단위 정수 설정 항목입니다.
+func durationField(ptr func(*Config) *time.Duration) field {
+	return field{
+		parse: func(c *Config, value string) error {
+			d, err := parseDuration(value)
+			if err != nil {
+				return err
+			}
+			*ptr(c) = d
+			return nil
+		},
+		format: func(c *Config) string { return ptr(c).String() },
+	}
+}
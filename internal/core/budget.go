@@ -0,0 +1,280 @@
+package core
+
+import (
+	"fmt"
+	"git-ai-commit/internal/git"
+	"git-ai-commit/internal/llm"
+	"git-ai-commit/internal/model"
+	"path/filepath"
+	"sort"
+	"strings"
+)
+
+// 디렉토리별 요약 설정
+const (
+	maxSummaryDirs = 20  // 요약에 표시할 최대 디렉토리 수
+	summaryReserve = 400 // 디렉토리별 요약에 남겨두는 토큰 수
+)
+
+// BudgetReport는 토큰 예산에 맞춰 프롬프트를 구성한 결과입니다.
+type BudgetReport struct {
+	Budget    int          // 프롬프트 토큰 예산 (0이면 제한 없음)
+	Estimated int          // 최종 프롬프트(재추천 기록 포함)의 추정 토큰 수
+	Included  int          // 변경 내용을 그대로 포함한 파일 수
+	Dropped   []DirSummary // 예산 초과로 디렉토리별 요약으로 대체된 파일들
+}
+
+// DirSummary는 프롬프트에서 생략된 파일들의 디렉토리별 요약입니다.
+type DirSummary struct {
+	Dir     string   // 디렉토리 경로
+	Paths   []string // 생략된 파일 경로
+	Added   int      // 추가된 줄 수 합계
+	Deleted int      // 삭제된 줄 수 합계
+}
+
+// Truncated는 예산 초과로 생략된 파일이 있는지 확인합니다.
+func (r *BudgetReport) Truncated() bool {
+	return len(r.Dropped) > 0
+}
+
+// DroppedFiles는 생략된 파일 수를 반환합니다.
+func (r *BudgetReport) DroppedFiles() int {
+	count := 0
+	for _, summary := range r.Dropped {
+		count += len(summary.Paths)
+	}
+	return count
+}
+
+// PlanBudget은 GeneratePrompt가 만들 프롬프트의 크기와 생략되는 파일을 미리 계산합니다.
+func PlanBudget(input *model.GeneratorInput) *BudgetReport {
+	_, report := buildPrompt(input)
+	return report
+}
+
+// buildPrompt는 토큰 예산에 맞춰 프롬프트를 만들고 그 결과를 보고합니다.
+// 예산을 넘으면 소스 > 테스트 > 설정 > 문서 > 잠금/생성 파일 순서로 포함하고,
+// 나머지는 디렉토리별 요약으로 대체합니다.
+func buildPrompt(input *model.GeneratorInput) (string, *BudgetReport) {
+	diff := input.DiffResult
+	lang := input.Lang
+
+	var header strings.Builder
+	writePromptHeader(&header, diff, lang)
+	writeBreakingChanges(&header, diff.BreakingChanges, lang)
+	writeTickets(&header, input.Tickets, input.TicketMode, lang)
+	summarized := writeSummaries(&header, input.Summaries, lang)
+
+	var requirements strings.Builder
+	writeRequirements(&requirements, input.Detail, input.JSONMode, lang)
+	writeAllowedValues(&requirements, input.AllowedTypes, input.AllowedScopes, lang)
+	writeStyleGuide(&requirements, input.Style, input.JSONMode, lang)
+
+	report := &BudgetReport{Budget: input.TokenBudget}
+
+	// 예산 안에 들어가는 파일 선택
+	included := make(map[int]bool, len(diff.Files))
+	var dropped []git.FileChange
+	if input.TokenBudget <= 0 {
+		for i := range diff.Files {
+			included[i] = true
+		}
+	} else {
+		// 재추천 기록은 프롬프트 뒤에 대화로 붙으므로 그만큼 diff에 쓸 예산을 줄임
+		available := input.TokenBudget - llm.EstimateTokens(header.String()) - llm.EstimateTokens(requirements.String()) - summaryReserve - feedbackTokens(input)
+		used := 0
+		full := false
+		for _, i := range prioritizeFiles(diff.Files) {
+			tokens := llm.EstimateTokens(formatFileEntry(diff.Files[i], !summarized[diff.Files[i].Path]))
+			// 우선순위를 지키기 위해 한 번 넘치면 이후 파일은 모두 생략
+			if full || used+tokens > available {
+				full = true
+				dropped = append(dropped, diff.Files[i])
+				continue
+			}
+			used += tokens
+			included[i] = true
+		}
+	}
+	report.Included = len(included)
+	report.Dropped = summarizeByDirectory(dropped)
+
+	// 포함된 파일은 원래 순서대로 기록
+	var changes strings.Builder
+	if lang == "ko" {
+		changes.WriteString("변경 내용 요약:\n")
+	} else {
+		changes.WriteString("Changes summary:\n")
+	}
+	if len(diff.Files) == 0 {
+		changes.WriteString("변경된 파일이 없습니다.\n")
+	}
+	for i, file := range diff.Files {
+		if included[i] {
+			changes.WriteString(formatFileEntry(file, !summarized[file.Path]))
+		}
+	}
+	writeDroppedSummary(&changes, report.Dropped, lang)
+	changes.WriteString("\n")
+
+	prompt := header.String() + changes.String() + requirements.String()
+	report.Estimated = llm.EstimateTokens(prompt) + feedbackTokens(input)
+
+	return prompt, report
+}
+
+// writeSummaries는 파일 그룹별 요약을 기록하고, 요약에 포함된 파일 경로 집합을 반환합니다.
+func writeSummaries(builder *strings.Builder, summaries []model.ChangeSummary, lang string) map[string]bool {
+	summarized := make(map[string]bool)
+	if len(summaries) == 0 {
+		return summarized
+	}
+
+	if lang == "ko" {
+		builder.WriteString("파일 그룹별 변경 요약:\n")
+	} else {
+		builder.WriteString("Change summaries per file group:\n")
+	}
+	for _, summary := range summaries {
+		if lang == "ko" {
+			builder.WriteString(fmt.Sprintf("- %s (파일 %d개): %s\n", summary.Group, len(summary.Paths), summary.Summary))
+		} else {
+			builder.WriteString(fmt.Sprintf("- %s (%d files): %s\n", summary.Group, len(summary.Paths), summary.Summary))
+		}
+		for _, path := range summary.Paths {
+			summarized[path] = true
+		}
+	}
+	builder.WriteString("\n")
+
+	return summarized
+}
+
+// formatFileEntry는 파일 하나의 변경 요약 항목을 만듭니다.
+// withChanges가 false이면 (그룹 요약에 포함된 파일) 변경 내용 일부는 생략합니다.
+func formatFileEntry(file git.FileChange, withChanges bool) string {
+	var builder strings.Builder
+	builder.WriteString(fmt.Sprintf("- %s (%s)", file.Path, file.FileType.String()))
+
+	if file.IsNew {
+		builder.WriteString(" [새 파일]")
+	}
+	if file.IsDeleted {
+		builder.WriteString(" [삭제됨]")
+	}
+
+	builder.WriteString("\n")
+
+	// 변경 내용의 일부를 추가
+	if withChanges && file.Changes != "" {
+		summary := summarizeChanges(file.Changes)
+		if summary != "" {
+			builder.WriteString(fmt.Sprintf("  %s\n", summary))
+		}
+	}
+
+	return builder.String()
+}
+
+// filePriority는 예산이 부족할 때 파일을 포함할 우선순위를 반환합니다 (작을수록 우선).
+func filePriority(file git.FileChange) int {
+	if file.IsGenerated() {
+		return 4
+	}
+
+	switch file.FileType {
+	case git.FileTypeSource:
+		return 0
+	case git.FileTypeTest:
+		return 1
+	case git.FileTypeConfig:
+		return 2
+	default:
+		return 3
+	}
+}
+
+// prioritizeFiles는 파일 인덱스를 우선순위 순서로 정렬해 반환합니다.
+// 같은 우선순위 안에서는 원래 순서를 유지합니다.
+func prioritizeFiles(files []git.FileChange) []int {
+	order := make([]int, len(files))
+	for i := range order {
+		order[i] = i
+	}
+
+	sort.SliceStable(order, func(a, b int) bool {
+		return filePriority(files[order[a]]) < filePriority(files[order[b]])
+	})
+	return order
+}
+
+// summarizeByDirectory는 생략된 파일들을 디렉토리별로 묶고, 파일이 많은 순서로 정렬합니다.
+func summarizeByDirectory(files []git.FileChange) []DirSummary {
+	index := make(map[string]int)
+	var summaries []DirSummary
+
+	for _, file := range files {
+		dir := filepath.ToSlash(filepath.Dir(file.Path))
+
+		i, ok := index[dir]
+		if !ok {
+			i = len(summaries)
+			index[dir] = i
+			summaries = append(summaries, DirSummary{Dir: dir})
+		}
+
+		added, deleted := file.LineStats()
+		summaries[i].Paths = append(summaries[i].Paths, file.Path)
+		summaries[i].Added += added
+		summaries[i].Deleted += deleted
+	}
+
+	sort.SliceStable(summaries, func(a, b int) bool {
+		return len(summaries[a].Paths) > len(summaries[b].Paths)
+	})
+	return summaries
+}
+
+// writeDroppedSummary는 생략된 파일들의 디렉토리별 요약을 기록합니다.
+func writeDroppedSummary(builder *strings.Builder, summaries []DirSummary, lang string) {
+	if len(summaries) == 0 {
+		return
+	}
+
+	if lang == "ko" {
+		builder.WriteString("토큰 예산 초과로 생략된 파일 (디렉토리별 요약):\n")
+	} else {
+		builder.WriteString("Files omitted to fit the token budget (summarized per directory):\n")
+	}
+
+	for i, summary := range summaries {
+		if i == maxSummaryDirs {
+			rest := 0
+			for _, s := range summaries[i:] {
+				rest += len(s.Paths)
+			}
+			if lang == "ko" {
+				builder.WriteString(fmt.Sprintf("- 그 외 디렉토리 %d개 (파일 %d개)\n", len(summaries)-i, rest))
+			} else {
+				builder.WriteString(fmt.Sprintf("- %d more directories (%d files)\n", len(summaries)-i, rest))
+			}
+			break
+		}
+
+		if lang == "ko" {
+			builder.WriteString(fmt.Sprintf("- %s/ (파일 %d개, +%d/-%d)\n", summary.Dir, len(summary.Paths), summary.Added, summary.Deleted))
+		} else {
+			builder.WriteString(fmt.Sprintf("- %s/ (%d files, +%d/-%d)\n", summary.Dir, len(summary.Paths), summary.Added, summary.Deleted))
+		}
+	}
+}
+
+// feedbackTokens는 프롬프트 뒤에 대화로 붙는 재추천 기록의 추정 토큰 수를 반환합니다.
+func feedbackTokens(input *model.GeneratorInput) int {
+	tokens := 0
+	for _, feedback := range input.Feedback {
+		tokens += llm.EstimateTokens(formatCandidates(feedback.Rejected, input.JSONMode))
+		tokens += llm.EstimateTokens(regenerateRequest(feedback.Hint, input.JSONMode, input.Lang))
+	}
+	return tokens
+}
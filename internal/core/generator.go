@@ -1,33 +1,95 @@
 package core
 
 import (
-	"git-ai-commit/internal/git"
+	"context"
 	"git-ai-commit/internal/llm"
+	"git-ai-commit/internal/model"
+	"git-ai-commit/internal/ticket"
 )
 
 // Generator는 커밋 메시지를 생성하는 역할을 합니다.
 type Generator struct {
 	provider llm.Provider
+	opts     GeneratorOptions
+}
+
+// GeneratorOptions는 Generator의 동작을 설정합니다.
+type GeneratorOptions struct {
+	// 생성/잠금 파일을 제외한 파일 수나 변경 줄 수가 임계값 이상이면
+	// 파일 그룹별 요약을 먼저 만든 뒤 최종 프롬프트에 사용합니다 (0이면 해당 기준 사용 안 함).
+	SummaryFileThreshold int
+	SummaryLineThreshold int
+
+	// 동시에 보낼 요약 요청 수 (0이면 기본값)
+	SummaryWorkers int
 }
 
 // NewGenerator는 새로운 Generator 인스턴스를 생성합니다.
-func NewGenerator(provider llm.Provider) *Generator {
+func NewGenerator(provider llm.Provider, opts GeneratorOptions) *Generator {
 	return &Generator{
 		provider: provider,
+		opts:     opts,
 	}
 }
 
 // Generate는 diff를 분석하여 커밋 메시지 후보들을 생성합니다.
-func (g *Generator) Generate(diff *git.DiffResult, detail string, lang string) ([]string, error) {
-	// 프롬프트 생성
-	prompt := GeneratePrompt(diff, detail, lang)
+// ctx가 취소되면 LLM 호출을 중단하고 ctx의 에러를 반환합니다.
+func (g *Generator) Generate(ctx context.Context, input *model.GeneratorInput) ([]string, error) {
+	// 프롬프트와 재추천 기록으로 대화 구성
+	g.syncJSONMode(input)
+	conversation := buildConversation(input)
 
 	// LLM 호출
-	messages, err := g.provider.Generate(prompt)
+	messages, err := g.provider.Generate(ctx, conversation)
+	if err != nil {
+		return nil, err
+	}
+
+	// 형식 보정 (보정할 수 없는 후보는 한 번 다시 요청) 후 티켓 번호 추가
+	return applyTickets(g.repairCandidates(ctx, input, messages), input), nil
+}
+
+// GenerateStream은 Generate와 같지만, 생성 중인 후보를 onUpdate로 전달합니다.
+// 제공자가 스트리밍을 지원하지 않으면 완료 시점에 한 번만 호출됩니다.
+// 형식 보정은 생성이 끝난 뒤에 하므로, 실시간으로 보여준 후보와 반환한 후보는 다를 수 있습니다.
+func (g *Generator) GenerateStream(ctx context.Context, input *model.GeneratorInput, onUpdate llm.StreamFunc) ([]string, error) {
+	g.syncJSONMode(input)
+	messages, err := llm.GenerateStream(ctx, g.provider, buildConversation(input), onUpdate)
 	if err != nil {
 		return nil, err
 	}
+	return applyTickets(g.repairCandidates(ctx, input, messages), input), nil
+}
 
-	return messages, nil
+// syncJSONMode는 제공자가 JSON 응답 형식을 거부해 텍스트 모드로 바뀌었으면 input.JSONMode를 끕니다.
+// 대화 기록(이전 후보)과 형식 지시가 제공자의 현재 모드와 같도록, 대화를 만들기 전에 매번 호출합니다.
+func (g *Generator) syncJSONMode(input *model.GeneratorInput) {
+	if input.JSONMode && !llm.JSONMode(g.provider) {
+		input.JSONMode = false
+	}
+}
+
+// applyTickets는 브랜치에서 찾은 티켓 번호를 모든 후보에 footer나 제목 접두사로 추가합니다.
+func applyTickets(messages []string, input *model.GeneratorInput) []string {
+	if len(input.Tickets) == 0 {
+		return messages
+	}
+	for i, message := range messages {
+		messages[i] = ticket.Apply(message, input.Tickets, input.TicketMode)
+	}
+	return messages
+}
+
+// buildConversation은 프롬프트 뒤에 재추천 기록을 대화로 이어 붙입니다.
+// 재추천마다 이전 후보를 assistant 응답으로, 다른 후보 요청과 사용자 요청을 user 메시지로 추가합니다.
+func buildConversation(input *model.GeneratorInput) []llm.Message {
+	conversation := llm.UserMessages(GeneratePrompt(input))
+	for _, feedback := range input.Feedback {
+		conversation = append(conversation,
+			llm.Message{Role: llm.RoleAssistant, Content: formatCandidates(feedback.Rejected, input.JSONMode)},
+			llm.Message{Role: llm.RoleUser, Content: regenerateRequest(feedback.Hint, input.JSONMode, input.Lang)},
+		)
+	}
+	return conversation
 }
 // Add language support
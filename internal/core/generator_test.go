@@ -0,0 +1,105 @@
+package core
+
+import (
+	"context"
+	"git-ai-commit/internal/git"
+	"git-ai-commit/internal/llm"
+	"git-ai-commit/internal/model"
+	"strings"
+	"testing"
+)
+
+// downgradingProvider는 첫 호출에서 JSON 응답 형식을 거부당해 텍스트 모드로 바뀌는 제공자입니다.
+type downgradingProvider struct {
+	jsonMode      bool
+	responses     [][]string
+	conversations [][]llm.Message
+}
+
+func (p *downgradingProvider) Generate(ctx context.Context, messages []llm.Message) ([]string, error) {
+	p.conversations = append(p.conversations, messages)
+	p.jsonMode = false
+	response := p.responses[0]
+	if len(p.responses) > 1 {
+		p.responses = p.responses[1:]
+	}
+	return response, nil
+}
+
+func (p *downgradingProvider) JSONMode() bool {
+	return p.jsonMode
+}
+
+func (p *downgradingProvider) Close() error {
+	return nil
+}
+
+func newTestInput() *model.GeneratorInput {
+	return &model.GeneratorInput{
+		DiffResult: &git.DiffResult{
+			Files: []git.FileChange{{Path: "internal/api/handler.go", Changes: "+func Handle() {}"}},
+		},
+		Detail:   "low",
+		Lang:     "en",
+		JSONMode: true,
+	}
+}
+
+// assertTextMode는 대화의 이전 후보와 형식 지시가 모두 번호 형식 텍스트인지 확인합니다.
+func assertTextMode(t *testing.T, conversation []llm.Message) {
+	t.Helper()
+	for _, message := range conversation {
+		if strings.Contains(message.Content, "JSON") || strings.HasPrefix(strings.TrimSpace(message.Content), "{") {
+			t.Errorf("%s message still uses the JSON format:\n%s", message.Role, message.Content)
+		}
+	}
+}
+
+func TestRepairUsesTextModeAfterDowngrade(t *testing.T) {
+	provider := &downgradingProvider{
+		jsonMode: true,
+		responses: [][]string{
+			{"feat(api): add handler", "fix(api): " + strings.Repeat("handle empty request bodies ", 5)},
+			{"fix(api): handle empty request bodies"},
+		},
+	}
+	input := newTestInput()
+	input.LintRules.MaxSubjectLength = 72
+
+	messages, err := NewGenerator(provider, GeneratorOptions{}).Generate(context.Background(), input)
+	if err != nil {
+		t.Fatalf("Generate: %v", err)
+	}
+
+	if len(provider.conversations) != 2 {
+		t.Fatalf("provider called %d times, want a generate and a repair request", len(provider.conversations))
+	}
+	if input.JSONMode {
+		t.Error("input.JSONMode is still true after the provider fell back to text mode")
+	}
+	repair := provider.conversations[1]
+	assertTextMode(t, repair)
+	if !strings.Contains(repair[len(repair)-1].Content, "numbered format") {
+		t.Errorf("repair request does not ask for the numbered format:\n%s", repair[len(repair)-1].Content)
+	}
+	if messages[1] != "fix(api): handle empty request bodies" {
+		t.Errorf("repaired candidate = %q", messages[1])
+	}
+}
+
+func TestRegenerateUsesTextModeAfterDowngrade(t *testing.T) {
+	// 앞선 생성에서 이미 텍스트 모드로 바뀐 제공자
+	provider := &downgradingProvider{responses: [][]string{{"feat(api): add request handler"}}}
+	input := newTestInput()
+	input.Feedback = []model.Feedback{{Rejected: []string{"feat(api): add handler"}, Hint: "mention the route"}}
+
+	if _, err := NewGenerator(provider, GeneratorOptions{}).Generate(context.Background(), input); err != nil {
+		t.Fatalf("Generate: %v", err)
+	}
+
+	conversation := provider.conversations[0]
+	assertTextMode(t, conversation)
+	if rejected := conversation[1].Content; !strings.HasPrefix(rejected, "1) feat(api): add handler") {
+		t.Errorf("rejected candidates = %q, want the numbered format", rejected)
+	}
+}
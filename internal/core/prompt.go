@@ -3,13 +3,23 @@ package core
 import (
 	"fmt"
 	"git-ai-commit/internal/git"
+	"git-ai-commit/internal/lint"
+	"git-ai-commit/internal/llm"
+	"git-ai-commit/internal/model"
+	"git-ai-commit/internal/ticket"
 	"strings"
 )
 
 // GeneratePrompt는 LLM에 전달할 프롬프트를 생성합니다.
-func GeneratePrompt(diff *git.DiffResult, detail string, lang string) string {
-	var builder strings.Builder
+// input.TokenBudget이 0보다 크면 예산 안에서 우선순위가 높은 파일부터 포함하고,
+// 나머지는 디렉토리별 요약으로 대체합니다 (PlanBudget 참고).
+func GeneratePrompt(input *model.GeneratorInput) string {
+	prompt, _ := buildPrompt(input)
+	return prompt
+}
 
+// writePromptHeader는 추천 타입, scope, 변경 패턴, 디렉토리 구조를 기록합니다.
+func writePromptHeader(builder *strings.Builder, diff *git.DiffResult, lang string) {
 	// 헤더
 	if lang == "ko" {
 		builder.WriteString("다음 정보를 기반으로 Conventional Commit 메시지를 생성하세요.\n\n")
@@ -19,9 +29,9 @@ func GeneratePrompt(diff *git.DiffResult, detail string, lang string) string {
 
 	// 추천 타입과 설명
 	if lang == "ko" {
-		builder.WriteString(fmt.Sprintf("추천 타입: %s (%s)\n", diff.CommitType, getCommitTypeDescription(diff.CommitType, lang)))
+		builder.WriteString(fmt.Sprintf("추천 타입: %s (%s)\n", diff.SuggestedType(), getCommitTypeDescription(diff.CommitType, lang)))
 	} else {
-		builder.WriteString(fmt.Sprintf("Recommended type: %s (%s)\n", diff.CommitType, getCommitTypeDescription(diff.CommitType, lang)))
+		builder.WriteString(fmt.Sprintf("Recommended type: %s (%s)\n", diff.SuggestedType(), getCommitTypeDescription(diff.CommitType, lang)))
 	}
 
 	// 추천 scope
@@ -55,39 +65,11 @@ func GeneratePrompt(diff *git.DiffResult, detail string, lang string) string {
 		}
 	}
 
-	// 변경 내용 요약
-	if lang == "ko" {
-		builder.WriteString("변경 내용 요약:\n")
-	} else {
-		builder.WriteString("Changes summary:\n")
-	}
-	if len(diff.Files) == 0 {
-		builder.WriteString("변경된 파일이 없습니다.\n")
-	} else {
-		for _, file := range diff.Files {
-			builder.WriteString(fmt.Sprintf("- %s (%s)", file.Path, file.FileType.String()))
-
-			if file.IsNew {
-				builder.WriteString(" [새 파일]")
-			}
-			if file.IsDeleted {
-				builder.WriteString(" [삭제됨]")
-			}
-
-			builder.WriteString("\n")
-
-			// 변경 내용의 일부를 추가
-			if file.Changes != "" {
-				summary := summarizeChanges(file.Changes)
-				if summary != "" {
-					builder.WriteString(fmt.Sprintf("  %s\n", summary))
-				}
-			}
-		}
-	}
-
-	builder.WriteString("\n")
+}
 
+// writeRequirements는 출력 형식 요구사항과 디테일 레벨별 지시를 기록합니다.
+// JSON 모드에서는 응답 형식을 system 지시문이 정하므로 번호 형식 지시를 넣지 않습니다.
+func writeRequirements(builder *strings.Builder, detail string, jsonMode bool, lang string) {
 	// 요구사항
 	if lang == "ko" {
 		builder.WriteString("요구사항:\n")
@@ -105,15 +87,21 @@ func GeneratePrompt(diff *git.DiffResult, detail string, lang string) string {
 	} else {
 		builder.WriteString("- Generate 3 candidates\n")
 	}
-	if lang == "ko" {
-		builder.WriteString("- 번호로 구분 (예: 1) feat(auth): ...)\n")
-	} else {
-		builder.WriteString("- Numbered format (e.g., 1) feat(auth): ...)\n")
+	if !jsonMode {
+		if lang == "ko" {
+			builder.WriteString("- 번호로 구분 (예: 1) feat(auth): ...)\n")
+		} else {
+			builder.WriteString("- Numbered format (e.g., 1) feat(auth): ...)\n")
+		}
 	}
 
 	// 디테일 레벨에 따른 추가 요구사항
 	switch detail {
 	case "high":
+		if jsonMode {
+			writeJSONBodyRequirements(builder, lang)
+			break
+		}
 		if lang == "ko" {
 			builder.WriteString("\n")
 			builder.WriteString("=== [REQUIRED] 다중 줄 형식 출력 ===\n")
@@ -181,9 +169,331 @@ func GeneratePrompt(diff *git.DiffResult, detail string, lang string) string {
 		}
 	}
 
+}
+
+// writeJSONBodyRequirements는 JSON 모드의 high 디테일 레벨에서 body 작성 형식을 기록합니다.
+func writeJSONBodyRequirements(builder *strings.Builder, lang string) {
+	if lang == "ko" {
+		builder.WriteString("\n")
+		builder.WriteString("=== [REQUIRED] 다중 줄 형식 출력 ===\n")
+		builder.WriteString("모든 후보의 body를 다음 형식으로 작성해야 합니다:\n\n")
+		builder.WriteString("예시 (type: feat, scope: auth, subject: 사용자 인증 기능 추가):\n")
+		builder.WriteString("- JWT 토큰 기반 인증 구현\n")
+		builder.WriteString("- 로그인/로그아웃 API 추가\n")
+		builder.WriteString("- 사용자 세션 관리 개선\n")
+		builder.WriteString("\n")
+		builder.WriteString("형식 규칙:\n")
+		builder.WriteString("1. subject에는 제목만 작성 (타입과 scope는 각 필드에)\n")
+		builder.WriteString("2. body에는 한 줄에 하나씩 bullet point (- 항목)\n")
+		builder.WriteString("3. 최소 2-3개의 bullet point 포함\n")
+	} else {
+		builder.WriteString("\n")
+		builder.WriteString("=== [REQUIRED] Multi-line Body ===\n")
+		builder.WriteString("The body of EVERY candidate MUST follow this format:\n\n")
+		builder.WriteString("Example (type: feat, scope: auth, subject: Add user authentication):\n")
+		builder.WriteString("- Implement JWT token authentication\n")
+		builder.WriteString("- Add login/logout APIs\n")
+		builder.WriteString("- Improve user session management\n")
+		builder.WriteString("\n")
+		builder.WriteString("Format Rules:\n")
+		builder.WriteString("1. subject: the title only (type and scope go in their own fields)\n")
+		builder.WriteString("2. body: one bullet point (- item) per line\n")
+		builder.WriteString("3. Include at least 2-3 bullet points\n")
+	}
+}
+
+// writeAllowedValues는 프로젝트 설정에서 허용한 타입과 scope만 사용하도록 요구사항을 기록합니다.
+func writeAllowedValues(builder *strings.Builder, types, scopes []string, lang string) {
+	if len(types) > 0 {
+		if lang == "ko" {
+			builder.WriteString(fmt.Sprintf("- 다음 타입만 사용: %s\n", strings.Join(types, ", ")))
+		} else {
+			builder.WriteString(fmt.Sprintf("- Use only these types: %s\n", strings.Join(types, ", ")))
+		}
+	}
+	if len(scopes) > 0 {
+		if lang == "ko" {
+			builder.WriteString(fmt.Sprintf("- 다음 scope만 사용 (맞는 scope가 없으면 생략): %s\n", strings.Join(scopes, ", ")))
+		} else {
+			builder.WriteString(fmt.Sprintf("- Use only these scopes (omit the scope if none fits): %s\n", strings.Join(scopes, ", ")))
+		}
+	}
+}
+
+// maxBreakingChanges는 프롬프트에 나열할 호환성을 깨는 변경의 최대 개수입니다.
+const maxBreakingChanges = 10
+
+// writeBreakingChanges는 diff에서 찾은 호환성을 깨는 변경을 나열하고,
+// 타입 뒤의 "!"와 영향을 설명하는 BREAKING CHANGE footer를 요청합니다.
+func writeBreakingChanges(builder *strings.Builder, changes []git.BreakingChange, lang string) {
+	if len(changes) == 0 {
+		return
+	}
+
+	if lang == "ko" {
+		builder.WriteString("호환성을 깨는 변경 (공개 API 분석):\n")
+	} else {
+		builder.WriteString("Breaking changes (from the public API diff):\n")
+	}
+	for i, change := range changes {
+		if i == maxBreakingChanges {
+			if lang == "ko" {
+				builder.WriteString(fmt.Sprintf("- 외 %d개\n", len(changes)-i))
+			} else {
+				builder.WriteString(fmt.Sprintf("- and %d more\n", len(changes)-i))
+			}
+			break
+		}
+		builder.WriteString("- " + DescribeBreakingChange(change, lang) + "\n")
+	}
+	if lang == "ko" {
+		builder.WriteString("모든 후보의 타입 뒤에 \"!\"를 붙이고 (예: feat(api)!: ...), 본문 끝에 \"BREAKING CHANGE: <설명>\" footer로 무엇이 바뀌었고 사용하는 코드를 어떻게 고쳐야 하는지 설명하세요.\n\n")
+	} else {
+		builder.WriteString("Add \"!\" after the type of every candidate (e.g. feat(api)!: ...) and end each message with a \"BREAKING CHANGE: <description>\" footer explaining what changed and how callers must update their code.\n\n")
+	}
+}
+
+// DescribeBreakingChange는 호환성을 깨는 변경 하나를 언어에 맞게 설명합니다.
+func DescribeBreakingChange(change git.BreakingChange, lang string) string {
+	descriptions := map[string]map[string]string{
+		git.BreakingRemoved: {
+			"en": "%[2]s removed or renamed (%[1]s)",
+			"ko": "%[2]s 삭제 또는 이름 변경 (%[1]s)",
+		},
+		git.BreakingSignature: {
+			"en": "%[2]s signature changed: %[3]s → %[4]s (%[1]s)",
+			"ko": "%[2]s 시그니처 변경: %[3]s → %[4]s (%[1]s)",
+		},
+		git.BreakingFile: {
+			"en": "%[1]s deleted",
+			"ko": "%[1]s 파일 삭제",
+		},
+		git.BreakingMajor: {
+			"en": "%[1]s major version bump: %[3]s → %[4]s",
+			"ko": "%[1]s 메이저 버전 변경: %[3]s → %[4]s",
+		},
+	}
+
+	format, ok := descriptions[change.Kind][lang]
+	if !ok {
+		format = descriptions[change.Kind]["en"]
+	}
+	if format == "" {
+		return change.Path
+	}
+	return fmt.Sprintf(format, change.Path, change.Name, change.Before, change.After)
+}
+
+// writeTickets는 브랜치 이름에서 찾은 티켓 번호를 기록합니다.
+// 티켓 번호는 생성 후 자동으로 추가하므로 메시지에는 넣지 않도록 요청합니다.
+func writeTickets(builder *strings.Builder, tickets []string, mode, lang string) {
+	if len(tickets) == 0 {
+		return
+	}
+	ids := strings.Join(tickets, ", ")
+
+	placement := map[string]map[string]string{
+		ticket.ModeFooter: {"en": fmt.Sprintf("a \"%s:\" footer", ticket.FooterToken), "ko": fmt.Sprintf("\"%s:\" footer로", ticket.FooterToken)},
+		ticket.ModePrefix: {"en": "a subject prefix", "ko": "제목 접두사로"},
+	}
+	if lang == "ko" {
+		builder.WriteString(fmt.Sprintf("관련 티켓 (브랜치 이름에서 추출): %s\n", ids))
+		builder.WriteString(fmt.Sprintf("티켓 내용에 맞게 설명하되, 티켓 번호는 %s 자동으로 추가되므로 메시지에 쓰지 마세요.\n\n", placement[mode]["ko"]))
+	} else {
+		builder.WriteString(fmt.Sprintf("Related tickets (from the branch name): %s\n", ids))
+		builder.WriteString(fmt.Sprintf("Describe the change in the context of these tickets, but do not write the ticket IDs; they are added automatically as %s.\n\n", placement[mode]["en"]))
+	}
+}
+
+// writeStyleGuide는 저장소의 최근 커밋에서 추론한 작성 스타일과 예시를 기록합니다.
+// 일반 Conventional Commit 요구사항과 다른 부분은 저장소 스타일을 따르도록 요구합니다.
+func writeStyleGuide(builder *strings.Builder, profile *model.StyleProfile, jsonMode bool, lang string) {
+	if profile == nil {
+		return
+	}
+	ko := lang == "ko"
+
+	builder.WriteString("\n")
+	if ko {
+		builder.WriteString(fmt.Sprintf("저장소 커밋 스타일 (최근 커밋 %d개 분석, 위 요구사항과 다르면 이 스타일을 따를 것):\n", profile.Commits))
+		builder.WriteString(fmt.Sprintf("- 제목 길이: 평균 %d자, %d자 이하\n", profile.AvgSubjectLength, profile.MaxSubjectLength))
+	} else {
+		builder.WriteString(fmt.Sprintf("Repository commit style (from the last %d commits; follow it where it differs from the requirements above):\n", profile.Commits))
+		builder.WriteString(fmt.Sprintf("- Subject length: about %d characters, at most %d\n", profile.AvgSubjectLength, profile.MaxSubjectLength))
+	}
+
+	switch {
+	case profile.Conventional && ko:
+		builder.WriteString("- Conventional Commit 형식 사용\n")
+	case profile.Conventional:
+		builder.WriteString("- Uses the Conventional Commit format\n")
+	case ko:
+		builder.WriteString("- Conventional Commit 형식(type(scope):)을 사용하지 않음, 예시와 같은 형식으로 작성\n")
+	default:
+		builder.WriteString("- Does NOT use the Conventional Commit format (type(scope):); write subjects like the examples\n")
+	}
+	if len(profile.Types) > 0 {
+		if ko {
+			builder.WriteString(fmt.Sprintf("- 자주 쓰는 타입: %s\n", strings.Join(profile.Types, ", ")))
+		} else {
+			builder.WriteString(fmt.Sprintf("- Common types: %s\n", strings.Join(profile.Types, ", ")))
+		}
+	}
+	if len(profile.Scopes) > 0 {
+		if ko {
+			builder.WriteString(fmt.Sprintf("- 자주 쓰는 scope: %s\n", strings.Join(profile.Scopes, ", ")))
+		} else {
+			builder.WriteString(fmt.Sprintf("- Common scopes: %s\n", strings.Join(profile.Scopes, ", ")))
+		}
+	}
+
+	if ko {
+		if profile.Capitalized {
+			builder.WriteString("- 제목 설명은 대문자로 시작\n")
+		} else {
+			builder.WriteString("- 제목 설명은 소문자로 시작\n")
+		}
+		if profile.TrailingPeriod {
+			builder.WriteString("- 제목 끝에 마침표 사용\n")
+		} else {
+			builder.WriteString("- 제목 끝에 마침표 없음\n")
+		}
+	} else {
+		if profile.Capitalized {
+			builder.WriteString("- Subject description starts with a capital letter\n")
+		} else {
+			builder.WriteString("- Subject description starts with a lowercase letter\n")
+		}
+		if profile.TrailingPeriod {
+			builder.WriteString("- Subject ends with a period\n")
+		} else {
+			builder.WriteString("- No period at the end of the subject\n")
+		}
+	}
+
+	if profile.TicketPrefix != "" {
+		if ko {
+			builder.WriteString(fmt.Sprintf("- 제목 앞에 티켓 번호 사용 (예: %s), 알 수 없으면 생략\n", profile.TicketPrefix))
+		} else {
+			builder.WriteString(fmt.Sprintf("- Subjects start with a ticket number (e.g. %s); omit it if unknown\n", profile.TicketPrefix))
+		}
+	}
+	if profile.Gitmoji {
+		if ko {
+			builder.WriteString("- 제목 앞에 gitmoji 사용\n")
+		} else {
+			builder.WriteString("- Subjects start with a gitmoji\n")
+		}
+	}
+
+	languages := map[string]map[string]string{
+		"en": {"en": "English", "ko": "영어"},
+		"ko": {"en": "Korean", "ko": "한국어"},
+	}
+	bodies := map[string]map[string]string{
+		"none":      {"en": "subject line only, no body", "ko": "본문 없이 제목만 작성"},
+		"bullets":   {"en": "bullet list (- item) after a blank line", "ko": "빈 줄 뒤에 목록(- 항목)으로 작성"},
+		"paragraph": {"en": "prose paragraph after a blank line", "ko": "빈 줄 뒤에 문단으로 작성"},
+	}
+	if ko {
+		builder.WriteString(fmt.Sprintf("- 언어: %s\n", languages[profile.Language][lang]))
+		builder.WriteString(fmt.Sprintf("- 본문: %s\n", bodies[profile.BodyStyle][lang]))
+	} else {
+		builder.WriteString(fmt.Sprintf("- Language: %s\n", languages[profile.Language]["en"]))
+		builder.WriteString(fmt.Sprintf("- Body: %s\n", bodies[profile.BodyStyle]["en"]))
+	}
+
+	if len(profile.Examples) > 0 {
+		switch {
+		case jsonMode && ko:
+			builder.WriteString("\n저장소의 최근 커밋 예시 (형식만 참고하고 내용은 복사하지 말 것):\n")
+		case jsonMode:
+			builder.WriteString("\nRecent commits from this repository (match the style, do not copy the content):\n")
+		case ko:
+			builder.WriteString("\n저장소의 최근 커밋 예시 (형식만 참고하고 내용은 복사하지 말 것, 후보는 계속 번호로 구분):\n")
+		default:
+			builder.WriteString("\nRecent commits from this repository (match the style, do not copy the content; keep numbering the candidates):\n")
+		}
+		for _, example := range profile.Examples {
+			builder.WriteString("---\n")
+			builder.WriteString(strings.TrimRight(example, " \t\n") + "\n")
+		}
+		builder.WriteString("---\n")
+	}
+}
+
+// formatCandidates는 이전 후보를 프롬프트에서 요구한 형식(JSON 모드면 JSON, 아니면 번호 형식)으로 이어 붙입니다.
+func formatCandidates(candidates []string, jsonMode bool) string {
+	if jsonMode {
+		return llm.FormatCandidatesJSON(candidates)
+	}
+
+	var builder strings.Builder
+	for i, candidate := range candidates {
+		if i > 0 {
+			builder.WriteString("\n")
+		}
+		builder.WriteString(fmt.Sprintf("%d) %s\n", i+1, strings.TrimRight(candidate, " \t\n")))
+	}
+	return builder.String()
+}
+
+// regenerateRequest는 이전 후보와 다른 후보를 요청하는 메시지를 만듭니다.
+// hint가 있으면 사용자의 요청을 우선 반영하도록 덧붙입니다.
+func regenerateRequest(hint string, jsonMode bool, lang string) string {
+	var builder strings.Builder
+	if lang == "ko" {
+		builder.WriteString(fmt.Sprintf("위 후보는 모두 선택되지 않았습니다. 이전 후보들과 분명히 다른 새 후보 3개를 %s 생성하세요.\n", sameFormat(jsonMode, lang)))
+		if hint != "" {
+			builder.WriteString(fmt.Sprintf("다음 사용자 요청을 반드시 반영하세요: %s\n", hint))
+		}
+	} else {
+		builder.WriteString(fmt.Sprintf("None of the candidates above were chosen. Generate 3 new candidates %s that are clearly different from all previous ones.\n", sameFormat(jsonMode, lang)))
+		if hint != "" {
+			builder.WriteString(fmt.Sprintf("You MUST follow this request from the user: %s\n", hint))
+		}
+	}
+	return builder.String()
+}
+
+// repairRequest는 형식 규칙을 어긴 후보(broken, 0부터)를 위반 사항과 함께 다시 요청하는 메시지를 만듭니다.
+func repairRequest(broken []int, problems [][]lint.Violation, jsonMode bool, lang string) string {
+	var builder strings.Builder
+	if lang == "ko" {
+		builder.WriteString("다음 후보가 커밋 메시지 형식 규칙을 지키지 않습니다:\n")
+	} else {
+		builder.WriteString("These candidates break the commit message format rules:\n")
+	}
+	for i, index := range broken {
+		messages := make([]string, len(problems[i]))
+		for j, v := range problems[i] {
+			messages[j] = v.Message
+		}
+		builder.WriteString(fmt.Sprintf("- %d) %s\n", index+1, strings.Join(messages, "; ")))
+	}
+	if lang == "ko" {
+		builder.WriteString(fmt.Sprintf("규칙을 지키는 대체 후보 %d개만 %s 생성하세요.\n", len(broken), sameFormat(jsonMode, lang)))
+	} else {
+		builder.WriteString(fmt.Sprintf("Generate only %d replacement candidates that follow the rules, %s.\n", len(broken), sameFormat(jsonMode, lang)))
+	}
 	return builder.String()
 }
 
+// sameFormat은 이전 응답과 같은 형식으로 생성하라는 요청 문구를 반환합니다.
+// JSON 모드에서는 system 지시문의 JSON 형식을, 텍스트 모드에서는 1부터 시작하는 번호 형식을 가리킵니다.
+func sameFormat(jsonMode bool, lang string) string {
+	switch {
+	case jsonMode && lang == "ko":
+		return "같은 JSON 형식으로"
+	case jsonMode:
+		return "in the same JSON format"
+	case lang == "ko":
+		return "같은 번호 형식으로 (1부터 번호)"
+	default:
+		return "in the same numbered format (numbered from 1)"
+	}
+}
+
 // summarizeChanges는 diff 변경 내용을 요약합니다.
 func summarizeChanges(changes string) string {
 	lines := strings.Split(changes, "\n")
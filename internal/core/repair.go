@@ -0,0 +1,272 @@
+package core
+
+import (
+	"context"
+	"git-ai-commit/internal/lint"
+	"git-ai-commit/internal/llm"
+	"git-ai-commit/internal/model"
+	"regexp"
+	"slices"
+	"strings"
+	"unicode/utf8"
+)
+
+// bodyWidth는 본문을 줄바꿈하는 폭입니다 (git 관례).
+const bodyWidth = 72
+
+var (
+	// 느슨한 헤더 형식: "Feat (API) : 설명", "fix(): 설명" 등도 허용
+	looseHeaderPattern = regexp.MustCompile(`^([A-Za-z]+)\s*(?:\(\s*([^()]*?)\s*\))?\s*(!)?\s*:\s*(.*)$`)
+	// "Refs: #12", "BREAKING CHANGE: ..." 같은 footer 줄 (마지막 문단에서만 footer로 봄)
+	footerPattern = regexp.MustCompile(`^(?:[A-Za-z][A-Za-z-]*|BREAKING CHANGE): \S|^[A-Za-z][A-Za-z-]* #\S`)
+	// 번호 목록 ("1. ", "2) ")
+	numberedPattern = regexp.MustCompile(`^\d+[.)] `)
+)
+
+// conventionalTypes는 Conventional Commit에서 널리 쓰는 타입입니다.
+var conventionalTypes = []string{"feat", "fix", "docs", "style", "refactor", "perf", "test", "build", "ci", "chore", "revert"}
+
+// typeAliases는 LLM이 자주 쓰는 타입 변형을 표준 타입으로 바꿉니다.
+var typeAliases = map[string]string{
+	"feature":       "feat",
+	"features":      "feat",
+	"bugfix":        "fix",
+	"hotfix":        "fix",
+	"bug":           "fix",
+	"doc":           "docs",
+	"documentation": "docs",
+	"tests":         "test",
+	"testing":       "test",
+	"refactoring":   "refactor",
+	"performance":   "perf",
+	"chores":        "chore",
+}
+
+// repairCandidates는 후보들의 형식을 로컬에서 보정합니다.
+// 보정한 뒤에도 형식 규칙을 어기는 후보는 한 번만 다시 요청해 바꿉니다.
+// 다시 요청한 후보도 규칙을 어기거나 요청에 실패하면 보정한 후보를 그대로 사용합니다.
+func (g *Generator) repairCandidates(ctx context.Context, input *model.GeneratorInput, candidates []string) []string {
+	repaired := make([]string, 0, len(candidates))
+	for _, candidate := range candidates {
+		if message := repairCandidate(candidate, input); message != "" {
+			repaired = append(repaired, message)
+		}
+	}
+
+	var broken []int
+	var problems [][]lint.Violation
+	for i, message := range repaired {
+		if violations := formatViolations(message, input); len(violations) > 0 {
+			broken = append(broken, i)
+			problems = append(problems, violations)
+		}
+	}
+	if len(broken) == 0 {
+		return repaired
+	}
+
+	// 첫 요청에서 제공자가 텍스트 모드로 바뀌었을 수 있으므로 형식을 다시 확인
+	g.syncJSONMode(input)
+	conversation := append(buildConversation(input),
+		llm.Message{Role: llm.RoleAssistant, Content: formatCandidates(repaired, input.JSONMode)},
+		llm.Message{Role: llm.RoleUser, Content: repairRequest(broken, problems, input.JSONMode, input.Lang)},
+	)
+	replacements, err := g.provider.Generate(ctx, conversation)
+	if err != nil {
+		return repaired
+	}
+
+	for i, index := range broken {
+		if i >= len(replacements) {
+			break
+		}
+		message := repairCandidate(replacements[i], input)
+		if message != "" && len(formatViolations(message, input)) == 0 {
+			repaired[index] = message
+		}
+	}
+	return repaired
+}
+
+// formatViolations는 다시 요청해야 하는 형식 위반을 반환합니다.
+// 명령형 여부는 표현의 문제이므로 선택 화면에서 경고만 표시합니다.
+func formatViolations(message string, input *model.GeneratorInput) []lint.Violation {
+	var violations []lint.Violation
+	for _, v := range lint.Lint(message, input.LintRules, input.Lang) {
+		if v.Rule != lint.RuleImperative {
+			violations = append(violations, v)
+		}
+	}
+	return violations
+}
+
+// repairCandidate는 후보 하나의 공백, 헤더 구조, 제목과 본문 사이 빈 줄, 본문 줄바꿈을 보정합니다.
+// 내용이 없으면 빈 문자열을 반환합니다.
+func repairCandidate(message string, input *model.GeneratorInput) string {
+	lines := strings.Split(strings.ReplaceAll(message, "\r\n", "\n"), "\n")
+	for i, line := range lines {
+		lines[i] = strings.TrimRight(strings.ReplaceAll(line, "\t", "    "), " ")
+	}
+
+	// 첫 번째 내용 있는 줄이 제목
+	for len(lines) > 0 && strings.TrimSpace(lines[0]) == "" {
+		lines = lines[1:]
+	}
+	if len(lines) == 0 {
+		return ""
+	}
+
+	subject := cleanSubject(lines[0])
+	if !slices.Contains(input.LintRules.Disabled, lint.RuleConventional) {
+		subject = repairHeader(subject, input)
+	}
+	if !slices.Contains(input.LintRules.Disabled, lint.RuleTrailingPeriod) && !strings.HasSuffix(subject, "...") {
+		subject = strings.TrimRight(subject, ".")
+	}
+	if subject == "" {
+		return ""
+	}
+
+	body := normalizeBody(lines[1:])
+	if body == "" {
+		return subject
+	}
+	return subject + "\n\n" + body
+}
+
+// cleanSubject는 제목 줄의 markdown 강조, 따옴표, 목록 기호와 중복 공백을 지웁니다.
+func cleanSubject(subject string) string {
+	subject = strings.TrimSpace(subject)
+	subject = strings.TrimPrefix(strings.TrimPrefix(subject, "- "), "* ")
+	subject = strings.ReplaceAll(subject, "**", "")
+	if len(subject) >= 2 && strings.ContainsAny(subject[:1], "\"'`") && subject[len(subject)-1] == subject[0] {
+		subject = subject[1 : len(subject)-1]
+	}
+	return strings.Join(strings.Fields(subject), " ")
+}
+
+// repairHeader는 type(scope): 설명 형식을 보정합니다.
+// 타입 대소문자와 별칭, 빈 scope, 콜론 주변 공백을 고치고, 타입이 없으면 추천 타입(호환성을 깨는 변경이면 "!" 포함)을 붙입니다.
+// 제목 앞의 티켓 번호 접두사는 그대로 둡니다.
+func repairHeader(subject string, input *model.GeneratorInput) string {
+	prefix, subject := lint.SplitTicketPrefix(subject)
+	if match := looseHeaderPattern.FindStringSubmatch(subject); match != nil {
+		commitType := strings.ToLower(match[1])
+		if alias, ok := typeAliases[commitType]; ok {
+			commitType = alias
+		}
+		if slices.Contains(conventionalTypes, commitType) || slices.Contains(input.AllowedTypes, commitType) {
+			description := strings.TrimSpace(match[4])
+			if description == "" {
+				return prefix + subject // 설명이 없으면 보정할 수 없음
+			}
+			header := commitType
+			if scope := strings.ReplaceAll(match[2], " ", ""); scope != "" {
+				header += "(" + scope + ")"
+			}
+			return prefix + header + match[3] + ": " + description
+		}
+	}
+
+	// 타입이 없으면 diff로 추천한 타입 사용
+	if input.DiffResult == nil || input.DiffResult.CommitType == "" {
+		return prefix + subject
+	}
+	return prefix + input.DiffResult.SuggestedType() + ": " + subject
+}
+
+// normalizeBody는 본문의 들여쓰기와 목록 기호를 정리하고 bodyWidth에 맞춰 줄바꿈합니다.
+// 연속된 빈 줄은 하나로 줄이고, 마지막 footer 문단은 줄바꿈하지 않습니다.
+func normalizeBody(lines []string) string {
+	footerStart := trailerStart(lines)
+	var out []string
+	inBullet := false
+	for i, line := range lines {
+		trimmed := strings.TrimSpace(line)
+		switch {
+		case trimmed == "":
+			if len(out) > 0 && out[len(out)-1] != "" {
+				out = append(out, "")
+			}
+			inBullet = false
+		case i >= footerStart:
+			out = append(out, trimmed)
+			inBullet = false
+		case isBullet(trimmed):
+			out = append(out, wrapLine("- "+strings.TrimSpace(trimmed[len(bulletPrefix(trimmed)):]), "  ")...)
+			inBullet = true
+		case numberedPattern.MatchString(trimmed):
+			out = append(out, wrapLine(trimmed, "   ")...)
+			inBullet = true
+		case inBullet && line != trimmed:
+			// 들여쓴 줄은 앞 목록 항목의 연속
+			out = append(out, wrapLine("  "+trimmed, "  ")...)
+		default:
+			out = append(out, wrapLine(trimmed, "")...)
+			inBullet = false
+		}
+	}
+	return strings.Trim(strings.Join(out, "\n"), "\n")
+}
+
+// trailerStart는 마지막 문단(빈 줄 뒤의 마지막 블록)의 모든 줄이 footer이면 그 문단의 시작 위치를,
+// 아니면 len(lines)를 반환합니다. 본문 중간의 "Note: ..." 같은 문장은 footer로 보지 않습니다.
+func trailerStart(lines []string) int {
+	end := len(lines)
+	for end > 0 && strings.TrimSpace(lines[end-1]) == "" {
+		end--
+	}
+	start := end
+	for start > 0 && strings.TrimSpace(lines[start-1]) != "" {
+		start--
+	}
+	if start == end {
+		return len(lines)
+	}
+	for _, line := range lines[start:end] {
+		if !footerPattern.MatchString(strings.TrimSpace(line)) {
+			return len(lines)
+		}
+	}
+	return start
+}
+
+// isBullet은 "-", "*", "•", "+" 목록 항목인지 확인합니다.
+func isBullet(line string) bool {
+	return bulletPrefix(line) != ""
+}
+
+// bulletPrefix는 목록 기호와 뒤의 공백을 반환합니다. 목록 항목이 아니면 빈 문자열을 반환합니다.
+func bulletPrefix(line string) string {
+	for _, prefix := range []string{"- ", "* ", "• ", "+ "} {
+		if strings.HasPrefix(line, prefix) {
+			return prefix
+		}
+	}
+	return ""
+}
+
+// wrapLine은 한 줄을 bodyWidth에 맞게 단어 단위로 나눕니다. 이어지는 줄은 indent로 들여씁니다.
+// bodyWidth보다 긴 단어(URL 등)는 나누지 않습니다.
+func wrapLine(line, indent string) []string {
+	if utf8.RuneCountInString(line) <= bodyWidth {
+		return []string{line}
+	}
+
+	// 줄 앞의 들여쓰기는 유지
+	lead := line[:len(line)-len(strings.TrimLeft(line, " "))]
+	var lines []string
+	current := lead
+	for _, word := range strings.Fields(line) {
+		switch {
+		case strings.TrimSpace(current) == "":
+			current += word
+		case utf8.RuneCountInString(current)+1+utf8.RuneCountInString(word) > bodyWidth:
+			lines = append(lines, current)
+			current = indent + word
+		default:
+			current += " " + word
+		}
+	}
+	return append(lines, current)
+}
@@ -0,0 +1,51 @@
+package core
+
+import (
+	"git-ai-commit/internal/model"
+	"strings"
+	"testing"
+	"unicode/utf8"
+)
+
+func TestRepairCandidateWrapsProseThatLooksLikeFooter(t *testing.T) {
+	note := "Note: the handler now rejects empty request bodies before decoding, so callers that relied on the old lenient behaviour must send an explicit empty JSON object instead."
+	breaking := "BREAKING CHANGE: empty request bodies are rejected with 400 instead of being decoded as an empty object by the handler."
+	message := strings.Join([]string{
+		"fix(api): reject empty request bodies",
+		"",
+		"Validate the body length before decoding.",
+		note,
+		"",
+		"Example: send {} when there is nothing to update, since an empty body is now an error for every endpoint.",
+		"",
+		breaking,
+		"Refs: #42",
+	}, "\n")
+
+	repaired := repairCandidate(message, &model.GeneratorInput{})
+
+	lines := strings.Split(repaired, "\n")
+	footerStart := len(lines) - 2
+	for i, line := range lines[:footerStart] {
+		if utf8.RuneCountInString(line) > bodyWidth {
+			t.Errorf("line %d is %d characters long (max %d): %q", i+1, utf8.RuneCountInString(line), bodyWidth, line)
+		}
+	}
+	if !strings.Contains(repaired, "\nNote: the handler now rejects") {
+		t.Errorf("the Note: sentence was not kept in the body:\n%s", repaired)
+	}
+	// 마지막 footer 문단은 길어도 줄바꿈하지 않음
+	if lines[footerStart] != breaking || lines[footerStart+1] != "Refs: #42" {
+		t.Errorf("footers = %q, want them unchanged", lines[footerStart:])
+	}
+}
+
+func TestRepairCandidateKeepsFooterOnlyBody(t *testing.T) {
+	footer := "BREAKING CHANGE: the --format flag was removed in favour of the json_mode setting in the config file."
+
+	repaired := repairCandidate("feat(cli)!: drop the --format flag\n\n"+footer, &model.GeneratorInput{})
+
+	if want := "feat(cli)!: drop the --format flag\n\n" + footer; repaired != want {
+		t.Errorf("repairCandidate = %q, want %q", repaired, want)
+	}
+}
@@ -0,0 +1,216 @@
+package core
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"git-ai-commit/internal/git"
+	"git-ai-commit/internal/llm"
+	"git-ai-commit/internal/model"
+	"git-ai-commit/internal/worker"
+	"path/filepath"
+	"sort"
+	"strings"
+)
+
+// 파일 그룹별 요약 설정
+const (
+	defaultSummaryWorkers = 4    // 동시에 보낼 요약 요청 수 (rate limit 고려)
+	maxGroupTokens        = 3000 // 요약 요청 하나에 넣는 diff의 최대 토큰 수
+	maxSummaryGroups      = 32   // 요약 요청의 최대 개수
+)
+
+// summarySystemMessage는 파일 그룹 요약 요청의 system 지시문입니다.
+const summarySystemMessage = `You summarize code changes for someone who will write a commit message.
+Reply with 1-3 short plain sentences describing what changed and why it matters.
+Do not use lists, markdown, or any preamble.`
+
+// fileGroup은 요약 요청 하나에 들어가는 파일 묶음입니다.
+type fileGroup struct {
+	name  string
+	files []git.FileChange
+}
+
+// NeedsSummary는 diff가 커서 파일 그룹별 요약을 먼저 만들어야 하는지 확인합니다.
+// 제공자가 텍스트 생성을 지원하지 않거나 임계값이 설정되지 않았으면 false입니다.
+func (g *Generator) NeedsSummary(diff *git.DiffResult) bool {
+	if _, ok := g.provider.(llm.TextGenerator); !ok {
+		return false
+	}
+
+	files := summarizableFiles(diff.Files)
+	if g.opts.SummaryFileThreshold > 0 && len(files) >= g.opts.SummaryFileThreshold {
+		return true
+	}
+
+	if g.opts.SummaryLineThreshold > 0 {
+		lines := 0
+		for _, file := range files {
+			added, deleted := file.LineStats()
+			lines += added + deleted
+		}
+		if lines >= g.opts.SummaryLineThreshold {
+			return true
+		}
+	}
+
+	return false
+}
+
+// Summarize는 diff를 파일 그룹으로 나눠 병렬로 요약합니다 (map 단계).
+// 결과는 input.Summaries에 넣어 최종 프롬프트에 사용합니다 (reduce 단계).
+// 일부 그룹이 실패하면 성공한 요약만 반환하고, 모두 실패하면 첫 번째 에러를 반환합니다.
+func (g *Generator) Summarize(ctx context.Context, input *model.GeneratorInput) ([]model.ChangeSummary, error) {
+	generator, ok := g.provider.(llm.TextGenerator)
+	if !ok {
+		return nil, errors.New("provider does not support text generation")
+	}
+
+	groups := groupFiles(summarizableFiles(input.DiffResult.Files))
+	if len(groups) == 0 {
+		return nil, nil
+	}
+
+	workers := g.opts.SummaryWorkers
+	if workers <= 0 {
+		workers = defaultSummaryWorkers
+	}
+
+	results := worker.Map(ctx, groups, workers, func(ctx context.Context, group fileGroup) (string, error) {
+		return generator.GenerateText(ctx, summarySystemMessage, buildSummaryPrompt(group))
+	})
+
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+
+	var summaries []model.ChangeSummary
+	var firstErr error
+	for i, result := range results {
+		if result.Err != nil {
+			if firstErr == nil {
+				firstErr = fmt.Errorf("%s: %w", groups[i].name, result.Err)
+			}
+			continue
+		}
+
+		summary := strings.Join(strings.Fields(result.Value), " ")
+		if summary == "" {
+			continue
+		}
+
+		paths := make([]string, len(groups[i].files))
+		for j, file := range groups[i].files {
+			paths[j] = file.Path
+		}
+		summaries = append(summaries, model.ChangeSummary{
+			Group:   groups[i].name,
+			Paths:   paths,
+			Summary: summary,
+		})
+	}
+
+	if len(summaries) == 0 && firstErr != nil {
+		return nil, firstErr
+	}
+	return summaries, nil
+}
+
+// summarizableFiles는 요약할 가치가 있는 파일(생성/잠금 파일 제외)만 반환합니다.
+func summarizableFiles(files []git.FileChange) []git.FileChange {
+	var result []git.FileChange
+	for _, file := range files {
+		if !file.IsGenerated() {
+			result = append(result, file)
+		}
+	}
+	return result
+}
+
+// groupFiles는 파일을 디렉토리별로 묶고, 요청 하나의 크기가 maxGroupTokens를 넘지 않도록 나눕니다.
+// 그룹이 maxSummaryGroups보다 많으면 상위 디렉토리 기준으로 다시 묶고,
+// 그래도 많으면 파일이 많은 그룹부터 maxSummaryGroups개만 요약합니다.
+func groupFiles(files []git.FileChange) []fileGroup {
+	var groups []fileGroup
+	for depth := -1; ; depth-- {
+		groups = chunkGroups(files, depth)
+		if len(groups) <= maxSummaryGroups || depth == -4 {
+			break
+		}
+	}
+
+	if len(groups) > maxSummaryGroups {
+		sort.SliceStable(groups, func(a, b int) bool {
+			return len(groups[a].files) > len(groups[b].files)
+		})
+		groups = groups[:maxSummaryGroups]
+	}
+	return groups
+}
+
+// chunkGroups는 파일을 디렉토리 기준으로 묶은 뒤 토큰 크기에 맞춰 나눕니다.
+// depth가 -1이면 전체 디렉토리 경로를, -2 이하이면 그만큼 상위 디렉토리를 기준으로 합니다.
+func chunkGroups(files []git.FileChange, depth int) []fileGroup {
+	index := make(map[string]int)
+	var groups []fileGroup
+	var sizes []int
+
+	for _, file := range files {
+		dir := groupDir(file.Path, depth)
+		tokens := min(llm.EstimateTokens(file.Changes), maxGroupTokens)
+
+		i, ok := index[dir]
+		if !ok || sizes[i]+tokens > maxGroupTokens {
+			i = len(groups)
+			index[dir] = i
+			groups = append(groups, fileGroup{name: dir})
+			sizes = append(sizes, 0)
+		}
+
+		groups[i].files = append(groups[i].files, file)
+		sizes[i] += tokens
+	}
+
+	return groups
+}
+
+// groupDir은 파일이 속할 그룹의 디렉토리를 반환합니다.
+func groupDir(path string, depth int) string {
+	dir := filepath.ToSlash(filepath.Dir(path))
+	if depth == -1 || dir == "." {
+		return dir
+	}
+
+	parts := strings.Split(dir, "/")
+	keep := len(parts) + depth + 1
+	if keep < 1 {
+		keep = 1
+	}
+	return strings.Join(parts[:keep], "/")
+}
+
+// buildSummaryPrompt는 파일 그룹 하나의 요약 요청 프롬프트를 만듭니다.
+// 너무 큰 파일의 diff는 maxGroupTokens 크기로 자릅니다.
+func buildSummaryPrompt(group fileGroup) string {
+	var builder strings.Builder
+	builder.WriteString(fmt.Sprintf("Summarize the following changes in %s:\n", group.name))
+
+	for _, file := range group.files {
+		builder.WriteString("\n### " + file.Path)
+		switch {
+		case file.IsNew:
+			builder.WriteString(" (new file)")
+		case file.IsDeleted:
+			builder.WriteString(" (deleted)")
+		}
+		builder.WriteString("\n")
+
+		changes := file.Changes
+		if limit := maxGroupTokens * 4; len(changes) > limit {
+			changes = changes[:limit] + "\n..."
+		}
+		builder.WriteString(changes + "\n")
+	}
+
+	return builder.String()
+}
@@ -0,0 +1,244 @@
+package credentials
+
+import (
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/rand"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+)
+
+// 파일 저장소의 파일 이름과 권한
+const (
+	credentialsFile = "credentials.json" // 제공자별 암호화된 API 키
+	keyFile         = "credentials.key"  // AES-256 로컬 키 (처음 저장할 때 생성)
+	keySize         = 32
+	filePerm        = 0600
+	dirPerm         = 0700
+)
+
+// FileStore는 API 키를 AES-GCM으로 암호화해 파일에 저장합니다.
+// 암호화 키는 같은 디렉토리의 별도 파일에 있으며, 두 파일 모두 소유자만 읽을 수 있습니다 (0600).
+// 디스크에 평문으로 남지 않게 하는 것이 목적이며, 같은 사용자 권한의 프로세스로부터 보호하지는 않습니다.
+type FileStore struct {
+	dir string
+}
+
+// NewFileStore는 dir에 credentials 파일을 저장하는 FileStore를 생성합니다.
+func NewFileStore(dir string) *FileStore {
+	return &FileStore{dir: dir}
+}
+
+// Location은 credentials 파일 경로를 반환합니다.
+func (s *FileStore) Location() string {
+	return filepath.Join(s.dir, credentialsFile)
+}
+
+// Get은 제공자의 API 키를 복호화해 반환합니다.
+func (s *FileStore) Get(provider string) (string, error) {
+	entries, err := s.load()
+	if err != nil {
+		return "", err
+	}
+
+	sealed, ok := entries[provider]
+	if !ok {
+		return "", ErrNotFound
+	}
+
+	key, err := s.readKey()
+	if errors.Is(err, os.ErrNotExist) {
+		return "", fmt.Errorf("credentials key %s is missing; run auth login again", filepath.Join(s.dir, keyFile))
+	}
+	if err != nil {
+		return "", err
+	}
+
+	return open(key, provider, sealed)
+}
+
+// Set은 제공자의 API 키를 암호화해 저장합니다. 암호화 키가 없으면 새로 만듭니다.
+func (s *FileStore) Set(provider, apiKey string) error {
+	entries, err := s.load()
+	if err != nil {
+		return err
+	}
+
+	key, err := s.readKey()
+	if errors.Is(err, os.ErrNotExist) {
+		key, err = s.createKey()
+	}
+	if err != nil {
+		return err
+	}
+
+	sealed, err := seal(key, provider, apiKey)
+	if err != nil {
+		return err
+	}
+	entries[provider] = sealed
+
+	return s.save(entries)
+}
+
+// Delete는 제공자의 API 키를 삭제합니다.
+func (s *FileStore) Delete(provider string) error {
+	entries, err := s.load()
+	if err != nil {
+		return err
+	}
+	if _, ok := entries[provider]; !ok {
+		return ErrNotFound
+	}
+
+	delete(entries, provider)
+	return s.save(entries)
+}
+
+// Providers는 API 키가 저장된 제공자 목록을 반환합니다 (복호화하지 않음).
+func (s *FileStore) Providers() ([]string, error) {
+	entries, err := s.load()
+	if err != nil {
+		return nil, err
+	}
+
+	providers := make([]string, 0, len(entries))
+	for provider := range entries {
+		providers = append(providers, provider)
+	}
+	sort.Strings(providers)
+	return providers, nil
+}
+
+// load는 credentials 파일을 읽습니다. 파일이 없으면 빈 목록을 반환합니다.
+func (s *FileStore) load() (map[string]string, error) {
+	path := s.Location()
+	data, err := os.ReadFile(path)
+	if errors.Is(err, os.ErrNotExist) {
+		return map[string]string{}, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to read %s: %w", path, err)
+	}
+
+	entries := make(map[string]string)
+	if err := json.Unmarshal(data, &entries); err != nil {
+		return nil, fmt.Errorf("failed to parse %s: %w", path, err)
+	}
+	return entries, nil
+}
+
+// save는 credentials 파일을 임시 파일에 쓴 뒤 교체합니다 (중간에 실패해도 기존 파일 유지).
+func (s *FileStore) save(entries map[string]string) error {
+	data, err := json.MarshalIndent(entries, "", "  ")
+	if err != nil {
+		return fmt.Errorf("failed to encode credentials: %w", err)
+	}
+	return s.writeFile(credentialsFile, data)
+}
+
+// readKey는 암호화 키를 읽습니다.
+func (s *FileStore) readKey() ([]byte, error) {
+	path := filepath.Join(s.dir, keyFile)
+	key, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	if len(key) != keySize {
+		return nil, fmt.Errorf("invalid credentials key %s", path)
+	}
+	return key, nil
+}
+
+// createKey는 새 암호화 키를 만들어 저장합니다.
+func (s *FileStore) createKey() ([]byte, error) {
+	key := make([]byte, keySize)
+	if _, err := rand.Read(key); err != nil {
+		return nil, fmt.Errorf("failed to generate credentials key: %w", err)
+	}
+	if err := s.writeFile(keyFile, key); err != nil {
+		return nil, err
+	}
+	return key, nil
+}
+
+// writeFile은 dir 아래에 소유자만 읽을 수 있는 파일을 원자적으로 씁니다.
+func (s *FileStore) writeFile(name string, data []byte) error {
+	if err := os.MkdirAll(s.dir, dirPerm); err != nil {
+		return fmt.Errorf("failed to create %s: %w", s.dir, err)
+	}
+
+	tmp, err := os.CreateTemp(s.dir, name+".*.tmp")
+	if err != nil {
+		return fmt.Errorf("failed to write %s: %w", name, err)
+	}
+	defer os.Remove(tmp.Name())
+
+	if err := tmp.Chmod(filePerm); err != nil {
+		tmp.Close()
+		return fmt.Errorf("failed to write %s: %w", name, err)
+	}
+	if _, err := tmp.Write(data); err != nil {
+		tmp.Close()
+		return fmt.Errorf("failed to write %s: %w", name, err)
+	}
+	if err := tmp.Close(); err != nil {
+		return fmt.Errorf("failed to write %s: %w", name, err)
+	}
+
+	if err := os.Rename(tmp.Name(), filepath.Join(s.dir, name)); err != nil {
+		return fmt.Errorf("failed to write %s: %w", name, err)
+	}
+	return nil
+}
+
+// seal은 API 키를 암호화해 base64(nonce || ciphertext)로 반환합니다.
+// 제공자 이름을 추가 인증 데이터로 사용해 다른 항목으로 옮겨도 복호화되지 않게 합니다.
+func seal(key []byte, provider, plaintext string) (string, error) {
+	gcm, err := newGCM(key)
+	if err != nil {
+		return "", err
+	}
+
+	nonce := make([]byte, gcm.NonceSize())
+	if _, err := rand.Read(nonce); err != nil {
+		return "", fmt.Errorf("failed to generate nonce: %w", err)
+	}
+
+	sealed := gcm.Seal(nonce, nonce, []byte(plaintext), []byte(provider))
+	return base64.StdEncoding.EncodeToString(sealed), nil
+}
+
+// open은 seal로 암호화한 API 키를 복호화합니다.
+func open(key []byte, provider, encoded string) (string, error) {
+	gcm, err := newGCM(key)
+	if err != nil {
+		return "", err
+	}
+
+	sealed, err := base64.StdEncoding.DecodeString(encoded)
+	if err != nil || len(sealed) < gcm.NonceSize() {
+		return "", fmt.Errorf("corrupted credential for %s", provider)
+	}
+
+	nonce, ciphertext := sealed[:gcm.NonceSize()], sealed[gcm.NonceSize():]
+	plaintext, err := gcm.Open(nil, nonce, ciphertext, []byte(provider))
+	if err != nil {
+		return "", fmt.Errorf("failed to decrypt credential for %s: %w", provider, err)
+	}
+	return string(plaintext), nil
+}
+
+// newGCM은 AES-256-GCM 암호기를 생성합니다.
+func newGCM(key []byte) (cipher.AEAD, error) {
+	block, err := aes.NewCipher(key)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create cipher: %w", err)
+	}
+	return cipher.NewGCM(block)
+}
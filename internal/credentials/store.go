@@ -0,0 +1,58 @@
+package credentials
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+// ErrNotFound는 저장된 API 키가 없을 때 반환됩니다.
+var ErrNotFound = errors.New("credential not found")
+
+// Store는 제공자별 API 키 저장소입니다.
+// 지금은 암호화된 파일(FileStore)만 있으며, OS 키링 등 다른 백엔드도 같은 인터페이스로 추가할 수 있습니다.
+type Store interface {
+	// Get은 제공자의 API 키를 반환합니다. 없으면 ErrNotFound를 반환합니다.
+	Get(provider string) (string, error)
+
+	// Set은 제공자의 API 키를 저장합니다. 이미 있으면 덮어씁니다.
+	Set(provider, key string) error
+
+	// Delete는 제공자의 API 키를 삭제합니다. 없으면 ErrNotFound를 반환합니다.
+	Delete(provider string) error
+
+	// Providers는 API 키가 저장된 제공자 목록을 정렬해 반환합니다.
+	Providers() ([]string, error)
+
+	// Location은 저장 위치를 사람이 읽을 수 있는 형식으로 반환합니다.
+	Location() string
+}
+
+// Providers는 API 키를 저장할 수 있는 제공자 목록입니다.
+var Providers = []string{"groq", "openai", "anthropic"}
+
+// NormalizeProvider는 제공자 이름을 저장용 이름으로 변환합니다 (claude → anthropic).
+// 지원하지 않는 제공자이면 에러를 반환합니다.
+func NormalizeProvider(provider string) (string, error) {
+	provider = strings.ToLower(strings.TrimSpace(provider))
+	if provider == "claude" {
+		provider = "anthropic"
+	}
+	for _, p := range Providers {
+		if provider == p {
+			return provider, nil
+		}
+	}
+	return "", fmt.Errorf("unknown provider: %s (supported: %s)", provider, strings.Join(Providers, ", "))
+}
+
+// DefaultStore는 ~/.git-ai-commit 아래의 암호화된 credentials 파일 저장소를 반환합니다.
+func DefaultStore() (Store, error) {
+	homeDir, err := os.UserHomeDir()
+	if err != nil {
+		return nil, fmt.Errorf("failed to get home directory: %w", err)
+	}
+	return NewFileStore(filepath.Join(homeDir, ".git-ai-commit")), nil
+}
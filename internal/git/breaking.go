@@ -0,0 +1,231 @@
+package git
+
+import (
+	"path"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// 호환성을 깨는 변경의 종류
+const (
+	BreakingRemoved   = "removed"   // 공개 식별자 삭제 또는 이름 변경
+	BreakingSignature = "signature" // 공개 함수 시그니처 변경
+	BreakingFile      = "file"      // 공개 파일 삭제
+	BreakingMajor     = "major"     // go.mod/package.json 메이저 버전 변경
+)
+
+// BreakingChange는 diff에서 찾은 호환성을 깨는 변경 하나입니다.
+type BreakingChange struct {
+	Kind   string // 변경 종류 (Breaking* 상수)
+	Path   string // 변경된 파일 경로
+	Name   string // 식별자 이름 (메서드는 "Type.Method")
+	Before string // 변경 전 (시그니처, 버전)
+	After  string // 변경 후 (시그니처, 버전)
+}
+
+var (
+	// 최상위 함수와 메서드 선언: func (r *Recv[T]) Name[T any](args) results {
+	goFuncPattern = regexp.MustCompile(`^func\s+(?:\(\s*(?:\w+\s+)?\*?\s*(\w+)(?:\[[^\]]*\])?\s*\)\s*)?(\w+)(.*)$`)
+	// 최상위 type/var/const 선언 (한 줄 선언만)
+	goDeclPattern = regexp.MustCompile(`^(?:type|var|const)\s+(\w+)\b`)
+	// go.mod의 module 경로 (/vN 접미사가 메이저 버전)
+	goModulePattern = regexp.MustCompile(`^module\s+(\S+?)(?:/v(\d+))?\s*$`)
+	// package.json의 최상위 version
+	packageVersionPattern = regexp.MustCompile(`^\s*"version"\s*:\s*"v?(\d+)\.[^"]*"`)
+)
+
+// publicSourceExts는 삭제하면 다른 코드에서 import할 수 없게 되는 라이브러리 소스 확장자입니다.
+// 스크립트 등 다른 소스 파일의 삭제는 호환성을 깨는 변경으로 보지 않습니다.
+var publicSourceExts = map[string]bool{
+	".js": true, ".mjs": true, ".ts": true, ".py": true, ".rb": true, ".java": true, ".kt": true,
+	".rs": true, ".php": true, ".cs": true, ".swift": true, ".dart": true, ".ex": true,
+}
+
+// goDecl은 diff 한 쪽(-/+)에 나온 공개 선언입니다.
+type goDecl struct {
+	path      string
+	signature string // 함수만 사용 (이름 뒤의 타입 매개변수, 인자, 반환값)
+	isFunc    bool
+}
+
+// DetectBreakingChanges는 diff에서 공개 API의 호환성을 깨는 변경을 찾습니다.
+// Go 공개 식별자의 삭제/이름 변경과 함수 시그니처 변경, 공개 소스 파일 삭제,
+// go.mod module 경로와 package.json version의 메이저 버전 증가를 찾습니다.
+// internal 디렉토리, main 패키지, 테스트 파일은 공개 API가 아니므로 검사하지 않습니다.
+// 여러 줄에 걸친 선언과 묶음(const ( ... )) 안의 선언은 검사하지 않습니다.
+func DetectBreakingChanges(files []FileChange) []BreakingChange {
+	var changes []BreakingChange
+
+	// 같은 패키지(디렉토리) 안에서 파일을 옮긴 선언은 삭제로 보지 않도록 패키지 단위로 비교
+	removed := make(map[string]goDecl)
+	added := make(map[string]goDecl)
+
+	for _, file := range files {
+		switch base := path.Base(file.Path); {
+		case base == "go.mod":
+			if before, after, ok := majorBump(file.Changes, goModuleMajor); ok {
+				changes = append(changes, BreakingChange{Kind: BreakingMajor, Path: file.Path, Before: before, After: after})
+			}
+			continue
+		case base == "package.json":
+			if before, after, ok := majorBump(file.Changes, packageMajor); ok {
+				changes = append(changes, BreakingChange{Kind: BreakingMajor, Path: file.Path, Before: before, After: after})
+			}
+			continue
+		}
+
+		if file.FileType != FileTypeSource || !isPublicPath(file.Path) {
+			continue
+		}
+		isGo := strings.HasSuffix(file.Path, ".go")
+		if isGo && isMainPackage(file.Changes) {
+			continue
+		}
+		if file.IsDeleted && (isGo || publicSourceExts[path.Ext(file.Path)]) {
+			changes = append(changes, BreakingChange{Kind: BreakingFile, Path: file.Path})
+		}
+		if !isGo {
+			continue
+		}
+
+		pkg := path.Dir(file.Path)
+		for _, line := range strings.Split(file.Changes, "\n") {
+			var decls map[string]goDecl
+			switch {
+			case strings.HasPrefix(line, "---"), strings.HasPrefix(line, "+++"):
+				continue
+			case strings.HasPrefix(line, "-"):
+				decls = removed
+			case strings.HasPrefix(line, "+"):
+				decls = added
+			default:
+				continue
+			}
+			if name, decl, ok := parseGoDecl(line[1:]); ok {
+				decl.path = file.Path
+				decls[pkg+"\x00"+name] = decl
+			}
+		}
+	}
+
+	keys := make([]string, 0, len(removed))
+	for key := range removed {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+
+	for _, key := range keys {
+		before := removed[key]
+		name := key[strings.IndexByte(key, 0)+1:]
+		after, ok := added[key]
+		switch {
+		case !ok:
+			changes = append(changes, BreakingChange{Kind: BreakingRemoved, Path: before.path, Name: name})
+		case before.isFunc && after.isFunc && before.signature != after.signature:
+			changes = append(changes, BreakingChange{
+				Kind:   BreakingSignature,
+				Path:   after.path,
+				Name:   name,
+				Before: before.signature,
+				After:  after.signature,
+			})
+		}
+	}
+
+	return changes
+}
+
+// parseGoDecl은 한 줄이 최상위 공개 선언이면 이름과 선언 정보를 반환합니다.
+// 비공개 타입의 메서드는 공개 API가 아니므로 제외합니다.
+func parseGoDecl(line string) (string, goDecl, bool) {
+	if match := goFuncPattern.FindStringSubmatch(line); match != nil {
+		receiver, name := match[1], match[2]
+		if !isExported(name) || (receiver != "" && !isExported(receiver)) {
+			return "", goDecl{}, false
+		}
+		if receiver != "" {
+			name = receiver + "." + name
+		}
+		signature := strings.TrimSpace(strings.TrimSuffix(strings.TrimSpace(match[3]), "{"))
+		return name, goDecl{signature: strings.Join(strings.Fields(signature), " "), isFunc: true}, true
+	}
+	if match := goDeclPattern.FindStringSubmatch(line); match != nil && isExported(match[1]) {
+		return match[1], goDecl{}, true
+	}
+	return "", goDecl{}, false
+}
+
+// isExported는 Go 식별자가 공개(대문자로 시작)인지 확인합니다.
+func isExported(name string) bool {
+	return name != "" && name[0] >= 'A' && name[0] <= 'Z'
+}
+
+// isPublicPath는 외부에서 사용할 수 있는 경로인지 확인합니다.
+// internal, testdata, vendor 디렉토리와 숨김 디렉토리는 공개 경로가 아닙니다.
+func isPublicPath(filePath string) bool {
+	for _, part := range strings.Split(path.Dir(filePath), "/") {
+		if part == "internal" || part == "testdata" || part == "vendor" || (strings.HasPrefix(part, ".") && part != ".") {
+			return false
+		}
+	}
+	return true
+}
+
+// isMainPackage는 변경 내용의 package 선언이 main인지 확인합니다.
+func isMainPackage(changes string) bool {
+	for _, line := range strings.Split(changes, "\n") {
+		line = strings.TrimLeft(line, "+- ")
+		if strings.HasPrefix(line, "package ") {
+			return strings.TrimSpace(strings.TrimPrefix(line, "package ")) == "main"
+		}
+	}
+	return false
+}
+
+// majorBump는 삭제된 줄과 추가된 줄에서 메이저 버전을 읽어 버전이 올라갔는지 확인합니다.
+func majorBump(changes string, parse func(line string) (int, bool)) (before, after string, bumped bool) {
+	oldMajor, newMajor := -1, -1
+	for _, line := range strings.Split(changes, "\n") {
+		switch {
+		case strings.HasPrefix(line, "---"), strings.HasPrefix(line, "+++"):
+		case strings.HasPrefix(line, "-"):
+			if major, ok := parse(line[1:]); ok {
+				oldMajor = major
+			}
+		case strings.HasPrefix(line, "+"):
+			if major, ok := parse(line[1:]); ok {
+				newMajor = major
+			}
+		}
+	}
+	// 0.x에서 1.0으로 올리는 것은 첫 안정 버전이므로 제외
+	if oldMajor < 1 || newMajor <= oldMajor {
+		return "", "", false
+	}
+	return "v" + strconv.Itoa(oldMajor), "v" + strconv.Itoa(newMajor), true
+}
+
+// goModuleMajor는 go.mod의 module 줄에서 메이저 버전을 읽습니다 (접미사가 없으면 v1).
+func goModuleMajor(line string) (int, bool) {
+	match := goModulePattern.FindStringSubmatch(strings.TrimSpace(line))
+	if match == nil {
+		return 0, false
+	}
+	if match[2] == "" {
+		return 1, true
+	}
+	major, err := strconv.Atoi(match[2])
+	return major, err == nil
+}
+
+// packageMajor는 package.json의 version 줄에서 메이저 버전을 읽습니다.
+func packageMajor(line string) (int, bool) {
+	match := packageVersionPattern.FindStringSubmatch(line)
+	if match == nil {
+		return 0, false
+	}
+	major, err := strconv.Atoi(match[1])
+	return major, err == nil
+}
@@ -1,15 +1,18 @@
 package git
 
 import (
+	"errors"
 	"fmt"
 	"os/exec"
+	"strconv"
 	"strings"
 )
 
 // Commit은 git commit을 실행합니다.
-func Commit(message string) error {
+// allowEmpty가 true면 staged된 변경 사항이 없어도 커밋합니다.
+func Commit(message string, allowEmpty bool) error {
 	// commit 실행
-	cmd := exec.Command("git", "commit", "-m", message)
+	cmd := exec.Command("git", CommitArgs(message, allowEmpty)...)
 
 	output, err := cmd.CombinedOutput()
 	if err != nil {
@@ -20,6 +23,35 @@ func Commit(message string) error {
 	return nil
 }
 
+// CommitArgs는 Commit이 실행하는 git 인자를 반환합니다 ("git" 제외).
+func CommitArgs(message string, allowEmpty bool) []string {
+	args := []string{"commit"}
+	if allowEmpty {
+		args = append(args, "--allow-empty")
+	}
+	return append(args, "-m", message)
+}
+
+// FormatCommand는 git 인자를 셸에 그대로 붙여 넣을 수 있는 명령어 문자열로 변환합니다.
+// 공백이나 특수 문자가 있는 인자는 작은따옴표로 감쌉니다.
+func FormatCommand(args []string) string {
+	quoted := make([]string, 0, len(args)+1)
+	quoted = append(quoted, "git")
+	for _, arg := range args {
+		quoted = append(quoted, ShellQuote(arg))
+	}
+	return strings.Join(quoted, " ")
+}
+
+// ShellQuote는 POSIX 셸에서 안전하도록 인자를 작은따옴표로 감쌉니다.
+// 셸에서 특별한 의미가 없는 문자만 있으면 그대로 반환합니다.
+func ShellQuote(arg string) string {
+	if arg != "" && strings.Trim(arg, "abcdefghijklmnopqrstuvwxyzABCDEFGHIJKLMNOPQRSTUVWXYZ0123456789-_=./:@%+,") == "" {
+		return arg
+	}
+	return "'" + strings.ReplaceAll(arg, "'", `'\''`) + "'"
+}
+
 // IsCleanWorkingTree는 working tree가 clean한지 확인합니다.
 func IsCleanWorkingTree() (bool, error) {
 	// status 확인
@@ -48,3 +80,70 @@ func GetStagedFiles() ([]string, error) {
 	files := strings.Split(strings.TrimSpace(string(output)), "\n")
 	return files, nil
 }
+
+// GetRepoRoot는 현재 git 저장소의 최상위 디렉토리 경로를 반환합니다.
+func GetRepoRoot() (string, error) {
+	cmd := exec.Command("git", "rev-parse", "--show-toplevel")
+	output, err := cmd.Output()
+	if err != nil {
+		return "", fmt.Errorf("git rev-parse 실패: %w", err)
+	}
+
+	return strings.TrimSpace(string(output)), nil
+}
+
+// GetHeadCommit은 HEAD 커밋의 해시를 반환합니다. 아직 커밋이 없으면 빈 문자열을 반환합니다.
+func GetHeadCommit() (string, error) {
+	output, err := exec.Command("git", "rev-parse", "--verify", "--quiet", "HEAD").Output()
+	if err != nil {
+		// --quiet이면 HEAD를 해석할 수 없을 때(커밋 없음) 메시지 없이 종료 코드 1
+		var exitErr *exec.ExitError
+		if errors.As(err, &exitErr) && exitErr.ExitCode() == 1 {
+			return "", nil
+		}
+		return "", fmt.Errorf("git rev-parse HEAD 실패: %w", err)
+	}
+	return strings.TrimSpace(string(output)), nil
+}
+
+// GetRecentCommitMessages는 현재 브랜치의 최근 커밋 메시지를 최신순으로 최대 n개 반환합니다.
+// merge 커밋은 자동 생성된 메시지가 많으므로 제외합니다. 커밋이 없으면 빈 목록을 반환합니다.
+func GetRecentCommitMessages(n int) ([]string, error) {
+	// 아직 커밋이 없는 저장소
+	if err := exec.Command("git", "rev-parse", "--verify", "--quiet", "HEAD").Run(); err != nil {
+		return []string{}, nil
+	}
+
+	cmd := exec.Command("git", "log", "-n", strconv.Itoa(n), "--no-merges", "--format=%B%x00")
+	output, err := cmd.Output()
+	if err != nil {
+		return nil, fmt.Errorf("git log 실패: %w", err)
+	}
+
+	var messages []string
+	for _, message := range strings.Split(string(output), "\x00") {
+		if message = strings.TrimSpace(message); message != "" {
+			messages = append(messages, message)
+		}
+	}
+	return messages, nil
+}
+
+// GetCurrentBranch는 현재 브랜치 이름을 반환합니다.
+// detached HEAD이면 빈 문자열을 반환합니다. 아직 커밋이 없는 저장소에서도 브랜치 이름을 반환합니다.
+func GetCurrentBranch() (string, error) {
+	output, err := exec.Command("git", "rev-parse", "--abbrev-ref", "HEAD").Output()
+	if err != nil {
+		// 커밋이 없으면 HEAD를 해석할 수 없으므로 심볼릭 참조에서 이름을 읽음
+		output, err = exec.Command("git", "symbolic-ref", "--short", "HEAD").Output()
+		if err != nil {
+			return "", fmt.Errorf("git rev-parse --abbrev-ref HEAD 실패: %w", err)
+		}
+	}
+
+	branch := strings.TrimSpace(string(output))
+	if branch == "HEAD" {
+		return "", nil
+	}
+	return branch, nil
+}
@@ -0,0 +1,74 @@
+package git
+
+import (
+	"errors"
+	"fmt"
+	"os/exec"
+	"regexp"
+	"strings"
+)
+
+// GetConfigSection은 지정한 범위(system, global, local)의 git config에서
+// "<section>.<key>" 형식 키의 값을 key → value로 반환합니다.
+// 같은 키가 여러 번 있으면 마지막 값을 사용합니다.
+func GetConfigSection(scope, section string) (map[string]string, error) {
+	cmd := exec.Command("git", "config", "--"+scope, "--null", "--get-regexp", "^"+regexp.QuoteMeta(section)+`\.`)
+	output, err := cmd.Output()
+	if err != nil {
+		// 일치하는 키가 없으면 종료 코드 1
+		var exitErr *exec.ExitError
+		if errors.As(err, &exitErr) && exitErr.ExitCode() == 1 {
+			return map[string]string{}, nil
+		}
+		if exitErr != nil && len(exitErr.Stderr) > 0 {
+			return nil, fmt.Errorf("git config --%s 실패: %w: %s", scope, err, strings.TrimSpace(string(exitErr.Stderr)))
+		}
+		return nil, fmt.Errorf("git config --%s 실패: %w", scope, err)
+	}
+
+	// --null 출력 형식: "<name>\n<value>\x00"
+	values := make(map[string]string)
+	for _, entry := range strings.Split(string(output), "\x00") {
+		if entry == "" {
+			continue
+		}
+		name, value, _ := strings.Cut(entry, "\n")
+		key := strings.TrimPrefix(name, section+".")
+		values[key] = value
+	}
+	return values, nil
+}
+
+// GetEditor는 git이 커밋 메시지 편집에 사용하는 편집기 명령어를 반환합니다.
+// git var가 GIT_EDITOR, core.editor, VISUAL, EDITOR 순서로 확인하고 없으면 기본 편집기를 반환합니다.
+func GetEditor() (string, error) {
+	cmd := exec.Command("git", "var", "GIT_EDITOR")
+	output, err := cmd.Output()
+	if err != nil {
+		return "", fmt.Errorf("git var GIT_EDITOR 실패: %w", err)
+	}
+	return strings.TrimSpace(string(output)), nil
+}
+
+// GetCommentChar는 커밋 메시지의 주석 문자 설정(core.commentChar, core.commentString)을 반환합니다.
+// git처럼 두 키 중 마지막에 설정된 값을 사용하고, 설정이 없으면 "#"을 반환합니다.
+// "auto"는 그대로 반환하므로 실제 문자는 lint.CommentChar나 lint.ChooseCommentChar로 정합니다.
+func GetCommentChar() (string, error) {
+	cmd := exec.Command("git", "config", "--null", "--get-regexp", `^core\.comment(char|string)$`)
+	output, err := cmd.Output()
+	if err != nil {
+		var exitErr *exec.ExitError
+		if errors.As(err, &exitErr) && exitErr.ExitCode() == 1 {
+			return "#", nil
+		}
+		return "", fmt.Errorf("git config core.commentChar 실패: %w", err)
+	}
+
+	commentChar := "#"
+	for _, entry := range strings.Split(string(output), "\x00") {
+		if _, value, ok := strings.Cut(entry, "\n"); ok && value != "" {
+			commentChar = value
+		}
+	}
+	return commentChar, nil
+}
@@ -56,10 +56,20 @@ type FileChange struct {
 
 // DiffResult는 파싱된 diff 결과를 담습니다.
 type DiffResult struct {
-	Files      []FileChange // 변경된 파일 목록
-	CommitType string       // 추론된 커밋 타입
-	Scopes     []string     // 추론된 scope 목록
-	RawDiff    string       // 원본 diff 문자열
+	Files           []FileChange     // 변경된 파일 목록
+	CommitType      string           // 추론된 커밋 타입
+	Scopes          []string         // 추론된 scope 목록
+	RawDiff         string           // 원본 diff 문자열
+	Breaking        bool             // 호환성을 깨는 변경 포함 여부
+	BreakingChanges []BreakingChange // 호환성을 깨는 변경 목록
+}
+
+// SuggestedType은 추천 타입을 반환합니다. 호환성을 깨는 변경이 있으면 "!"를 붙입니다 (예: "feat!").
+func (d *DiffResult) SuggestedType() string {
+	if d.Breaking && d.CommitType != "" {
+		return d.CommitType + "!"
+	}
+	return d.CommitType
 }
 
 // GetCachedDiff는 git diff --cached 명령을 실행하여 결과를 반환합니다.
@@ -114,6 +124,8 @@ func GetCachedDiff() (*DiffResult, error) {
 
 	result.CommitType = InferCommitType(result.Files)
 	result.Scopes = InferScopes(result.Files)
+	result.BreakingChanges = DetectBreakingChanges(result.Files)
+	result.Breaking = len(result.BreakingChanges) > 0
 
 	return result, nil
 }
@@ -481,6 +493,59 @@ func isDependencyFile(path string) bool {
 	return false
 }
 
+// 생성되거나 외부에서 가져온 파일로 간주하는 경로
+var (
+	generatedDirs = []string{"vendor/", "node_modules/", "third_party/", "dist/"}
+
+	generatedSuffixes = []string{
+		".lock", "-lock.json", "-lock.yaml", ".sum",
+		".pb.go", "_gen.go", ".gen.go", "_generated.go", ".generated.ts",
+		".min.js", ".min.css", ".map", ".snap",
+	}
+)
+
+// IsGeneratedFile은 경로로 보아 잠금 파일, 생성된 코드, vendor 디렉토리 등
+// 사람이 직접 작성하지 않은 파일인지 확인합니다.
+func IsGeneratedFile(path string) bool {
+	slashed := "/" + filepath.ToSlash(path)
+	for _, dir := range generatedDirs {
+		if strings.Contains(slashed, "/"+dir) {
+			return true
+		}
+	}
+
+	base := filepath.Base(path)
+	for _, suffix := range generatedSuffixes {
+		if strings.HasSuffix(base, suffix) {
+			return true
+		}
+	}
+	return strings.HasPrefix(base, "zz_generated")
+}
+
+// IsGenerated는 파일이 생성된 파일인지 확인합니다.
+// 경로 외에도 "Code generated ... DO NOT EDIT." 헤더가 추가되었는지 확인합니다.
+func (f FileChange) IsGenerated() bool {
+	if IsGeneratedFile(f.Path) {
+		return true
+	}
+	return strings.Contains(f.Changes, "+// Code generated") && strings.Contains(f.Changes, "DO NOT EDIT")
+}
+
+// LineStats는 diff 내용에서 추가/삭제된 줄 수를 셉니다.
+func (f FileChange) LineStats() (added, deleted int) {
+	for _, line := range strings.Split(f.Changes, "\n") {
+		switch {
+		case strings.HasPrefix(line, "+++"), strings.HasPrefix(line, "---"):
+		case strings.HasPrefix(line, "+"):
+			added++
+		case strings.HasPrefix(line, "-"):
+			deleted++
+		}
+	}
+	return added, deleted
+}
+
 // InferScopes는 파일 경로에서 scope를 추론합니다.
 func InferScopes(files []FileChange) []string {
 	if len(files) == 0 {
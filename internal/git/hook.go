@@ -0,0 +1,24 @@
+package git
+
+import (
+	"fmt"
+	"os/exec"
+	"path/filepath"
+	"strings"
+)
+
+// GetHooksDir은 git 훅 디렉토리의 절대 경로를 반환합니다.
+// core.hooksPath와 worktree 설정을 git이 직접 해석하도록 rev-parse --git-path를 사용합니다.
+func GetHooksDir() (string, error) {
+	cmd := exec.Command("git", "rev-parse", "--git-path", "hooks")
+	output, err := cmd.Output()
+	if err != nil {
+		return "", fmt.Errorf("git rev-parse --git-path 실패: %w", err)
+	}
+
+	dir, err := filepath.Abs(strings.TrimSpace(string(output)))
+	if err != nil {
+		return "", fmt.Errorf("훅 디렉토리 경로 변환 실패: %w", err)
+	}
+	return dir, nil
+}
@@ -0,0 +1,91 @@
+package lint
+
+import (
+	"strings"
+	"unicode"
+)
+
+// commonVerbs는 커밋 제목에 자주 쓰는 동사의 원형입니다.
+// 과거형("added"), 진행형("fixing"), 3인칭 단수형("adds")은 이 목록에 있는 동사만 판단합니다
+// ("embedded", "nested" 같은 형용사나 "docs", "tests" 같은 명사와 구분).
+var commonVerbs = []string{
+	"add", "adjust", "allow", "apply", "avoid", "bump", "change", "clean", "configure", "convert",
+	"correct", "create", "delete", "deprecate", "disable", "document", "drop", "enable", "ensure", "expose",
+	"extract", "fix", "handle", "implement", "improve", "include", "introduce", "make", "merge", "migrate",
+	"move", "optimize", "prevent", "reduce", "refactor", "remove", "rename", "replace", "resolve", "restore",
+	"return", "revert", "rewrite", "rework", "set", "simplify", "skip", "split", "support", "switch",
+	"update", "upgrade", "use", "validate",
+}
+
+// irregularForms는 규칙으로 만들 수 없는 과거형과 진행형입니다 (불규칙 동사, 끝 자음을 겹쳐 쓰는 동사).
+var irregularForms = map[string][]string{
+	"drop":    {"dropped", "dropping"},
+	"make":    {"made"},
+	"rewrite": {"rewrote", "rewritten"},
+	"set":     {"setting"},
+	"skip":    {"skipped", "skipping"},
+	"split":   {"splitting"},
+}
+
+// nonImperativeForms는 commonVerbs의 과거형, 진행형, 3인칭 단수형입니다.
+var nonImperativeForms = inflectVerbs(commonVerbs)
+
+// inflectVerbs는 동사 원형들의 과거형, 진행형, 3인칭 단수형 집합을 만듭니다.
+// 과거형이 원형과 같은 동사("set", "split")는 명령형과 구분할 수 없으므로 과거형을 검사하지 않습니다.
+func inflectVerbs(verbs []string) map[string]bool {
+	forms := make(map[string]bool)
+	for _, verb := range verbs {
+		stem, consonantY := verb, false
+		if n := len(verb); n > 1 && verb[n-1] == 'y' && !strings.ContainsRune("aeiou", rune(verb[n-2])) {
+			stem, consonantY = verb[:n-1], true
+		}
+
+		switch {
+		case consonantY:
+			forms[stem+"ies"] = true
+			forms[stem+"ied"] = true
+		case strings.HasSuffix(verb, "e"):
+			forms[verb+"s"] = true
+			forms[verb+"d"] = true
+		case strings.HasSuffix(verb, "s"), strings.HasSuffix(verb, "x"), strings.HasSuffix(verb, "z"),
+			strings.HasSuffix(verb, "ch"), strings.HasSuffix(verb, "sh"):
+			forms[verb+"es"] = true
+			forms[verb+"ed"] = true
+		default:
+			forms[verb+"s"] = true
+			forms[verb+"ed"] = true
+		}
+
+		if strings.HasSuffix(verb, "e") && !strings.HasSuffix(verb, "ee") {
+			forms[verb[:len(verb)-1]+"ing"] = true
+		} else {
+			forms[verb+"ing"] = true
+		}
+
+		for _, form := range irregularForms[verb] {
+			forms[form] = true
+		}
+	}
+	return forms
+}
+
+// nonImperativeVerb는 영어 설명의 첫 단어가 명령형이 아니면 그 단어와 true를 반환합니다.
+// "added", "fixing", "updates"처럼 commonVerbs의 과거형, 진행형, 3인칭 단수형을 찾습니다.
+// 영어로 시작하지 않는 설명(한국어 등)은 검사하지 않습니다.
+func nonImperativeVerb(description string) (string, bool) {
+	fields := strings.Fields(description)
+	if len(fields) == 0 {
+		return "", false
+	}
+	word := strings.ToLower(strings.TrimRight(fields[0], ",:;"))
+	for _, r := range word {
+		if r > unicode.MaxASCII || !unicode.IsLetter(r) {
+			return "", false
+		}
+	}
+
+	if nonImperativeForms[word] {
+		return fields[0], true
+	}
+	return "", false
+}
@@ -0,0 +1,52 @@
+package lint
+
+import "testing"
+
+func TestNonImperativeVerb(t *testing.T) {
+	tests := []struct {
+		description string
+		want        bool
+	}{
+		// 과거형, 진행형, 3인칭 단수형
+		{"added login endpoint", true},
+		{"Fixed nil pointer in parser", true},
+		{"applied review comments", true},
+		{"dropped support for go 1.20", true},
+		{"made retries configurable", true},
+		{"updating dependencies", true},
+		{"removing dead code", true},
+		{"skipping empty files", true},
+		{"fixes race in cache", true},
+		{"simplifies config loading", true},
+
+		// 명령형
+		{"add login endpoint", false},
+		{"set default timeout", false},
+		{"split large diffs", false},
+		{"use the new API", false},
+
+		// "-ed", "-ing", "-s"로 끝나는 형용사와 명사
+		{"embedded assets for the web ui", false},
+		{"nested config keys", false},
+		{"red button on error", false},
+		{"seed data for tests", false},
+		{"existing users keep their settings", false},
+		{"missing translations", false},
+		{"string helpers", false},
+		{"docs for hook install", false},
+		{"tests for the parser", false},
+
+		// 영어가 아닌 설명
+		{"로그인 엔드포인트 추가", false},
+		{"", false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.description, func(t *testing.T) {
+			word, got := nonImperativeVerb(tt.description)
+			if got != tt.want {
+				t.Errorf("nonImperativeVerb(%q) = %q, %t; want %t", tt.description, word, got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,229 @@
+package lint
+
+import (
+	"fmt"
+	"regexp"
+	"slices"
+	"strings"
+	"unicode/utf8"
+)
+
+// 규칙 이름 (설정의 lint_disable에 사용)
+const (
+	RuleConventional   = "conventional"    // type(scope): description 형식
+	RuleType           = "type"            // 허용한 타입만 사용
+	RuleScope          = "scope"           // 허용한 scope만 사용
+	RuleSubjectLength  = "subject-length"  // 제목 길이 제한
+	RuleImperative     = "imperative"      // 명령형 동사로 시작 (영어 제목만)
+	RuleTrailingPeriod = "trailing-period" // 제목 끝에 마침표 없음
+	RuleBlankLine      = "blank-line"      // 제목과 본문 사이에 빈 줄
+)
+
+// RuleNames는 모든 규칙 이름입니다.
+var RuleNames = []string{
+	RuleConventional, RuleType, RuleScope, RuleSubjectLength,
+	RuleImperative, RuleTrailingPeriod, RuleBlankLine,
+}
+
+// DefaultMaxSubjectLength는 제목 길이 기본 제한입니다 (git 관례).
+const DefaultMaxSubjectLength = 72
+
+var (
+	// type(scope)!: description
+	conventionalPattern = regexp.MustCompile(`^([a-z]+)(?:\(([^()\s]+)\))?(!)?: (\S.*)$`)
+	// 제목 앞의 티켓 번호 ("[PROJ-1234] ", "PROJ-1234: ")는 형식 검사에서 제외
+	ticketPrefixPattern = regexp.MustCompile(`^(?:\[[^\]]+\]|[A-Z][A-Z0-9]+-\d+:?)\s+`)
+	// git이 만드는 메시지는 검사하지 않음
+	generatedPrefixes = []string{"Merge ", "Revert \"", "fixup! ", "squash! ", "amend! "}
+)
+
+// Rules는 검사 규칙 설정입니다.
+type Rules struct {
+	Types            []string // 허용하는 타입 (비어 있으면 제한 없음)
+	Scopes           []string // 허용하는 scope (비어 있으면 제한 없음)
+	MaxSubjectLength int      // 제목 최대 길이 (0이면 검사 안 함)
+	Disabled         []string // 검사하지 않을 규칙 이름
+}
+
+// Violation은 규칙 위반 하나입니다.
+type Violation struct {
+	Rule    string // 위반한 규칙 이름
+	Message string // 사용자에게 보여줄 설명
+}
+
+func (v Violation) String() string {
+	return fmt.Sprintf("%s (%s)", v.Message, v.Rule)
+}
+
+// Lint는 커밋 메시지를 검사하고 위반 목록을 반환합니다. 위반이 없으면 nil을 반환합니다.
+// merge, revert, fixup! 등 git이 만드는 메시지는 검사하지 않습니다.
+func Lint(message string, rules Rules, lang string) []Violation {
+	message = strings.Trim(message, "\n")
+	lines := strings.Split(message, "\n")
+	subject := strings.TrimRight(lines[0], " \t")
+
+	for _, prefix := range generatedPrefixes {
+		if strings.HasPrefix(subject, prefix) {
+			return nil
+		}
+	}
+
+	var violations []Violation
+	report := func(rule, key string, args ...any) {
+		if slices.Contains(rules.Disabled, rule) {
+			return
+		}
+		violations = append(violations, Violation{Rule: rule, Message: fmt.Sprintf(getMessage(key, lang), args...)})
+	}
+
+	_, header := SplitTicketPrefix(subject)
+	description := header
+	if match := conventionalPattern.FindStringSubmatch(header); match != nil {
+		commitType, scope := match[1], match[2]
+		description = match[4]
+		if len(rules.Types) > 0 && !slices.Contains(rules.Types, commitType) {
+			report(RuleType, "type_not_allowed", commitType, strings.Join(rules.Types, ", "))
+		}
+		if scope != "" && len(rules.Scopes) > 0 && !slices.Contains(rules.Scopes, scope) {
+			report(RuleScope, "scope_not_allowed", scope, strings.Join(rules.Scopes, ", "))
+		}
+	} else {
+		report(RuleConventional, "not_conventional")
+	}
+
+	if length := utf8.RuneCountInString(subject); rules.MaxSubjectLength > 0 && length > rules.MaxSubjectLength {
+		report(RuleSubjectLength, "subject_too_long", length, rules.MaxSubjectLength)
+	}
+	if word, ok := nonImperativeVerb(description); ok {
+		report(RuleImperative, "not_imperative", word)
+	}
+	if strings.HasSuffix(subject, ".") && !strings.HasSuffix(subject, "...") {
+		report(RuleTrailingPeriod, "trailing_period")
+	}
+	if len(lines) > 1 && strings.TrimSpace(lines[1]) != "" {
+		report(RuleBlankLine, "missing_blank_line")
+	}
+
+	return violations
+}
+
+// SplitTicketPrefix는 제목을 앞의 티켓 번호 접두사("[PROJ-1234] ", "PROJ-1234: ")와 나머지로 나눕니다.
+// 접두사가 없으면 prefix는 빈 문자열입니다.
+func SplitTicketPrefix(subject string) (prefix, rest string) {
+	prefix = ticketPrefixPattern.FindString(subject)
+	return prefix, subject[len(prefix):]
+}
+
+// CommentAuto는 메시지에 쓰이지 않은 주석 문자를 git이 고르게 하는 core.commentChar 값입니다.
+const CommentAuto = "auto"
+
+// commentCandidates는 core.commentChar가 auto일 때 git이 순서대로 고려하는 주석 문자입니다.
+const commentCandidates = "#;@!$%^&|:"
+
+// ChooseCommentChar는 메시지에 주석을 덧붙일 때 사용할 주석 문자를 반환합니다.
+// 설정이 auto이면 git처럼 메시지의 줄 맨 앞에 쓰이지 않은 첫 후보 문자를 고릅니다.
+func ChooseCommentChar(setting, message string) string {
+	if setting != CommentAuto {
+		return setting
+	}
+	used := make(map[byte]bool)
+	for _, line := range strings.Split(message, "\n") {
+		if line != "" {
+			used[line[0]] = true
+		}
+	}
+	for i := 0; i < len(commentCandidates); i++ {
+		if !used[commentCandidates[i]] {
+			return commentCandidates[i : i+1]
+		}
+	}
+	return "#"
+}
+
+// CommentChar는 git이 이미 주석을 써 넣은 메시지 파일에서 사용 중인 주석 문자를 반환합니다.
+// 설정이 auto이면 scissors 줄이나 마지막 안내 주석 줄의 첫 문자로 판단하고, 찾지 못하면 "#"입니다.
+func CommentChar(setting, text string) string {
+	if setting != CommentAuto {
+		return setting
+	}
+	lines := strings.Split(strings.ReplaceAll(text, "\r\n", "\n"), "\n")
+	for _, line := range lines {
+		if len(line) > 1 && strings.IndexByte(commentCandidates, line[0]) >= 0 && isScissors(line, line[:1]) {
+			return line[:1]
+		}
+	}
+	for i := len(lines) - 1; i >= 0; i-- {
+		line := strings.TrimRight(lines[i], " \t")
+		if line == "" {
+			continue
+		}
+		if strings.IndexByte(commentCandidates, line[0]) >= 0 && (len(line) == 1 || line[1] == ' ' || line[1] == '\t') {
+			return line[:1]
+		}
+		break
+	}
+	return "#"
+}
+
+// StripComments는 편집기로 수정한 메시지에서 주석 문자로 시작하는 줄과 줄 끝 공백, 앞뒤 빈 줄을 지웁니다.
+// git commit -v의 scissors 줄(# ---- >8 ----) 아래의 diff도 지웁니다.
+func StripComments(text, commentChar string) string {
+	var lines []string
+	for _, line := range strings.Split(strings.ReplaceAll(text, "\r\n", "\n"), "\n") {
+		if isScissors(line, commentChar) {
+			break
+		}
+		if strings.HasPrefix(line, commentChar) {
+			continue
+		}
+		lines = append(lines, strings.TrimRight(line, " \t"))
+	}
+	return strings.Trim(strings.Join(lines, "\n"), "\n")
+}
+
+// isScissors는 줄이 git commit -v의 scissors 줄인지 확인합니다.
+func isScissors(line, commentChar string) bool {
+	return strings.HasPrefix(line, commentChar+" ") && strings.Contains(line, " >8 ")
+}
+
+// getMessage는 언어에 따른 위반 설명을 반환합니다.
+func getMessage(key, lang string) string {
+	messages := map[string]map[string]string{
+		"not_conventional": {
+			"en": "Subject does not follow the Conventional Commit format \"type(scope): description\"",
+			"ko": "제목이 Conventional Commit 형식(\"type(scope): 설명\")이 아닙니다",
+		},
+		"type_not_allowed": {
+			"en": "Type %q is not allowed (allowed: %s)",
+			"ko": "허용하지 않는 타입입니다: %q (허용: %s)",
+		},
+		"scope_not_allowed": {
+			"en": "Scope %q is not allowed (allowed: %s)",
+			"ko": "허용하지 않는 scope입니다: %q (허용: %s)",
+		},
+		"subject_too_long": {
+			"en": "Subject is %d characters long (max %d)",
+			"ko": "제목이 %d자입니다 (최대 %d자)",
+		},
+		"not_imperative": {
+			"en": "Subject should use the imperative mood (%q → e.g. \"add\", \"fix\")",
+			"ko": "제목은 명령형 동사로 시작해야 합니다 (%q → 예: \"add\", \"fix\")",
+		},
+		"trailing_period": {
+			"en": "Subject should not end with a period",
+			"ko": "제목 끝에 마침표를 붙이지 않습니다",
+		},
+		"missing_blank_line": {
+			"en": "Add a blank line between the subject and the body",
+			"ko": "제목과 본문 사이에 빈 줄이 필요합니다",
+		},
+	}
+
+	if msg, ok := messages[key]; ok {
+		if text, ok := msg[lang]; ok {
+			return text
+		}
+		return msg["en"]
+	}
+	return key
+}
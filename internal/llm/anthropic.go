@@ -0,0 +1,224 @@
+package llm
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+)
+
+// Anthropic 제공자의 기본값
+const (
+	anthropicDefaultBaseURL = "https://api.anthropic.com"
+	anthropicDefaultModel   = "claude-sonnet-4-5"
+	anthropicAPIVersion     = "2023-06-01"
+)
+
+// AnthropicProvider는 Anthropic Messages API를 사용하는 제공자입니다.
+// Messages API에는 응답 형식 옵션이 없으므로 JSON 모드와 관계없이 번호 형식 텍스트를 파싱합니다.
+type AnthropicProvider struct {
+	httpClient  *http.Client
+	apiKey      string
+	baseURL     string
+	model       string
+	temperature float32
+	maxTokens   int
+}
+
+// anthropicRequest는 Messages API 요청 본문입니다.
+// system 프롬프트는 messages가 아닌 최상위 필드로 전달합니다.
+type anthropicRequest struct {
+	Model       string             `json:"model"`
+	System      string             `json:"system,omitempty"`
+	Messages    []anthropicMessage `json:"messages"`
+	MaxTokens   int                `json:"max_tokens"`
+	Temperature float32            `json:"temperature"`
+	Stream      bool               `json:"stream,omitempty"`
+}
+
+type anthropicMessage struct {
+	Role    string `json:"role"`
+	Content string `json:"content"`
+}
+
+// anthropicResponse는 Messages API 응답 본문입니다.
+type anthropicResponse struct {
+	Content []struct {
+		Type string `json:"type"`
+		Text string `json:"text"`
+	} `json:"content"`
+	StopReason string `json:"stop_reason"`
+}
+
+// anthropicStreamEvent는 SSE 스트림의 data 이벤트입니다.
+type anthropicStreamEvent struct {
+	Type  string `json:"type"`
+	Delta struct {
+		Type string `json:"type"`
+		Text string `json:"text"`
+	} `json:"delta"`
+	Error struct {
+		Type    string `json:"type"`
+		Message string `json:"message"`
+	} `json:"error"`
+}
+
+// NewAnthropicProvider는 새로운 AnthropicProvider 인스턴스를 생성합니다.
+func NewAnthropicProvider(opts Options) (*AnthropicProvider, error) {
+	opts = opts.withDefaults(anthropicDefaultBaseURL, anthropicDefaultModel)
+	if opts.APIKey == "" {
+		return nil, fmt.Errorf("Anthropic API key is required")
+	}
+
+	return &AnthropicProvider{
+		httpClient:  &http.Client{},
+		apiKey:      opts.APIKey,
+		baseURL:     strings.TrimRight(opts.BaseURL, "/"),
+		model:       opts.Model,
+		temperature: opts.Temperature,
+		maxTokens:   opts.MaxTokens,
+	}, nil
+}
+
+// Generate는 Messages API를 호출하여 커밋 메시지 후보들을 생성합니다.
+func (p *AnthropicProvider) Generate(ctx context.Context, messages []Message) ([]string, error) {
+	text, err := p.complete(ctx, systemPrompt(false), messages)
+	if err != nil {
+		return nil, err
+	}
+
+	return parseResponse(text), nil
+}
+
+// GenerateText는 Messages API를 호출하여 text content block을 이어 붙인 응답을 반환합니다.
+func (p *AnthropicProvider) GenerateText(ctx context.Context, system, prompt string) (string, error) {
+	return p.complete(ctx, system, UserMessages(prompt))
+}
+
+// complete는 스트리밍 없이 Messages API를 호출하고 text content block을 이어 붙여 반환합니다.
+func (p *AnthropicProvider) complete(ctx context.Context, system string, messages []Message) (string, error) {
+	resp, err := p.send(ctx, system, messages, false)
+	if err != nil {
+		return "", err
+	}
+	defer resp.Body.Close()
+
+	var result anthropicResponse
+	if err := json.NewDecoder(resp.Body).Decode(&result); err != nil {
+		return "", fmt.Errorf("failed to decode response: %w", err)
+	}
+
+	// text 타입 content block만 이어 붙임
+	var text strings.Builder
+	for _, block := range result.Content {
+		if block.Type == "text" {
+			text.WriteString(block.Text)
+		}
+	}
+
+	if text.Len() == 0 {
+		return "", fmt.Errorf("no text content in response")
+	}
+
+	return text.String(), nil
+}
+
+// GenerateStream은 Messages API의 SSE 스트림으로 후보를 생성하며 진행 상황을 전달합니다.
+func (p *AnthropicProvider) GenerateStream(ctx context.Context, messages []Message, onUpdate StreamFunc) ([]string, error) {
+	resp, err := p.send(ctx, systemPrompt(false), messages, true)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	candidates := newCandidateStream(onUpdate, false)
+	scanner := bufio.NewScanner(resp.Body)
+	scanner.Buffer(make([]byte, 0, 64*1024), 1024*1024)
+
+	for scanner.Scan() {
+		data, ok := strings.CutPrefix(scanner.Text(), "data:")
+		if !ok {
+			continue
+		}
+
+		var event anthropicStreamEvent
+		if err := json.Unmarshal([]byte(strings.TrimSpace(data)), &event); err != nil {
+			continue
+		}
+
+		switch event.Type {
+		case "content_block_delta":
+			if event.Delta.Type == "text_delta" {
+				candidates.Write(event.Delta.Text)
+			}
+		case "error":
+			return nil, fmt.Errorf("failed to receive stream: %w", &APIError{
+				Provider: "anthropic",
+				// 스트림 중 에러는 HTTP 200 이후에 오므로 overloaded 등은 서버 에러로 취급
+				StatusCode: http.StatusServiceUnavailable,
+				Message:    event.Error.Type + ": " + event.Error.Message,
+			})
+		}
+	}
+
+	if err := scanner.Err(); err != nil {
+		return nil, fmt.Errorf("failed to receive stream: %w", err)
+	}
+
+	if candidates.Text() == "" {
+		return nil, fmt.Errorf("no text content in response")
+	}
+
+	return candidates.Result(), nil
+}
+
+// send는 Messages API 요청을 보내고, 성공 응답이 아니면 APIError를 반환합니다.
+func (p *AnthropicProvider) send(ctx context.Context, system string, messages []Message, stream bool) (*http.Response, error) {
+	chat := make([]anthropicMessage, len(messages))
+	for i, message := range messages {
+		chat[i] = anthropicMessage{Role: string(message.Role), Content: message.Content}
+	}
+
+	body, err := json.Marshal(anthropicRequest{
+		Model:       p.model,
+		System:      system,
+		Messages:    chat,
+		MaxTokens:   p.maxTokens,
+		Temperature: p.temperature,
+		Stream:      stream,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal request: %w", err)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, p.baseURL+"/v1/messages", bytes.NewReader(body))
+	if err != nil {
+		return nil, fmt.Errorf("failed to create request: %w", err)
+	}
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("x-api-key", p.apiKey)
+	req.Header.Set("anthropic-version", anthropicAPIVersion)
+
+	resp, err := p.httpClient.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("failed to generate completion: %w", err)
+	}
+
+	if resp.StatusCode != http.StatusOK {
+		defer resp.Body.Close()
+		data, _ := io.ReadAll(resp.Body)
+		return nil, fmt.Errorf("failed to generate completion: %w", newAPIError("anthropic", resp, data))
+	}
+
+	return resp, nil
+}
+
+// Close는 유휴 연결을 정리합니다.
+func (p *AnthropicProvider) Close() error {
+	p.httpClient.CloseIdleConnections()
+	return nil
+}
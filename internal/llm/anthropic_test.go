@@ -0,0 +1,265 @@
+package llm
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"testing"
+	"time"
+)
+
+// newTestAnthropic은 handler로 응답하는 httptest 서버에 연결된 AnthropicProvider를 만듭니다.
+func newTestAnthropic(t *testing.T, handler http.HandlerFunc) *AnthropicProvider {
+	t.Helper()
+
+	server := httptest.NewServer(handler)
+	t.Cleanup(server.Close)
+
+	provider, err := NewAnthropicProvider(Options{APIKey: "test-key", BaseURL: server.URL + "/", Model: "test-model"})
+	if err != nil {
+		t.Fatalf("NewAnthropicProvider: %v", err)
+	}
+	t.Cleanup(func() { provider.Close() })
+	return provider
+}
+
+// decodeAnthropicRequest는 요청의 경로와 헤더를 확인하고 본문을 디코딩합니다.
+func decodeAnthropicRequest(t *testing.T, r *http.Request) anthropicRequest {
+	t.Helper()
+
+	if r.Method != http.MethodPost || r.URL.Path != "/v1/messages" {
+		t.Errorf("request = %s %s, want POST /v1/messages", r.Method, r.URL.Path)
+	}
+	if got := r.Header.Get("x-api-key"); got != "test-key" {
+		t.Errorf("x-api-key = %q, want %q", got, "test-key")
+	}
+	if got := r.Header.Get("anthropic-version"); got != anthropicAPIVersion {
+		t.Errorf("anthropic-version = %q, want %q", got, anthropicAPIVersion)
+	}
+
+	var req anthropicRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		t.Fatalf("decode request: %v", err)
+	}
+	return req
+}
+
+func TestAnthropicGenerateSendsTopLevelSystem(t *testing.T) {
+	var got anthropicRequest
+	provider := newTestAnthropic(t, func(w http.ResponseWriter, r *http.Request) {
+		got = decodeAnthropicRequest(t, r)
+		fmt.Fprint(w, `{"content":[{"type":"text","text":"1) feat(api): add endpoint"}],"stop_reason":"end_turn"}`)
+	})
+
+	messages := []Message{
+		{Role: RoleUser, Content: "diff"},
+		{Role: RoleAssistant, Content: "1) fix: old"},
+		{Role: RoleUser, Content: "again"},
+	}
+	if _, err := provider.Generate(context.Background(), messages); err != nil {
+		t.Fatalf("Generate: %v", err)
+	}
+
+	if got.System != systemPrompt(false) {
+		t.Errorf("system = %q, want the text-mode system prompt", got.System)
+	}
+	want := []anthropicMessage{
+		{Role: "user", Content: "diff"},
+		{Role: "assistant", Content: "1) fix: old"},
+		{Role: "user", Content: "again"},
+	}
+	if !reflect.DeepEqual(got.Messages, want) {
+		t.Errorf("messages = %+v, want %+v", got.Messages, want)
+	}
+	if got.Model != "test-model" || got.MaxTokens != defaultMaxTokens || got.Stream {
+		t.Errorf("model = %q, max_tokens = %d, stream = %v", got.Model, got.MaxTokens, got.Stream)
+	}
+}
+
+func TestAnthropicGenerateConcatenatesTextBlocks(t *testing.T) {
+	provider := newTestAnthropic(t, func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprint(w, `{"content":[
+			{"type":"text","text":"1) feat(api): add "},
+			{"type":"tool_use","id":"toolu_1","name":"x","input":{}},
+			{"type":"text","text":"endpoint\n2) fix(db): close rows"}
+		],"stop_reason":"end_turn"}`)
+	})
+
+	candidates, err := provider.Generate(context.Background(), UserMessages("diff"))
+	if err != nil {
+		t.Fatalf("Generate: %v", err)
+	}
+	want := []string{"feat(api): add endpoint", "fix(db): close rows"}
+	if !reflect.DeepEqual(candidates, want) {
+		t.Errorf("candidates = %q, want %q", candidates, want)
+	}
+}
+
+func TestAnthropicGenerateTextConcatenatesTextBlocks(t *testing.T) {
+	var got anthropicRequest
+	provider := newTestAnthropic(t, func(w http.ResponseWriter, r *http.Request) {
+		got = decodeAnthropicRequest(t, r)
+		fmt.Fprint(w, `{"content":[
+			{"type":"text","text":"first "},
+			{"type":"tool_use","id":"toolu_1","name":"x","input":{}},
+			{"type":"text","text":"second"}
+		],"stop_reason":"end_turn"}`)
+	})
+
+	text, err := provider.GenerateText(context.Background(), "summarize", "diff")
+	if err != nil {
+		t.Fatalf("GenerateText: %v", err)
+	}
+	if text != "first second" {
+		t.Errorf("text = %q, want %q", text, "first second")
+	}
+	if got.System != "summarize" || len(got.Messages) != 1 || got.Messages[0].Role != "user" {
+		t.Errorf("request = %+v, want system %q and one user message", got, "summarize")
+	}
+}
+
+func TestAnthropicGenerateNoTextContent(t *testing.T) {
+	provider := newTestAnthropic(t, func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprint(w, `{"content":[{"type":"tool_use","id":"toolu_1","name":"x","input":{}}]}`)
+	})
+
+	if _, err := provider.GenerateText(context.Background(), "", "diff"); err == nil {
+		t.Fatal("GenerateText: want an error for a response without text blocks")
+	}
+}
+
+func TestAnthropicGenerateStream(t *testing.T) {
+	events := []string{
+		`event: message_start` + "\n" + `data: {"type":"message_start","message":{"id":"msg_1","content":[]}}`,
+		`event: content_block_start` + "\n" + `data: {"type":"content_block_start","index":0,"content_block":{"type":"text","text":""}}`,
+		`event: ping` + "\n" + `data: {"type":"ping"}`,
+		`event: content_block_delta` + "\n" + `data: {"type":"content_block_delta","index":0,"delta":{"type":"text_delta","text":"1) feat(api): add "}}`,
+		`event: content_block_delta` + "\n" + `data: {"type":"content_block_delta","index":0,"delta":{"type":"text_delta","text":"endpoint\n2) fix(db): close "}}`,
+		`event: content_block_delta` + "\n" + `data: {"type":"content_block_delta","index":0,"delta":{"type":"text_delta","text":"rows\n"}}`,
+		`event: content_block_stop` + "\n" + `data: {"type":"content_block_stop","index":0}`,
+		`event: message_stop` + "\n" + `data: {"type":"message_stop"}`,
+	}
+
+	var got anthropicRequest
+	provider := newTestAnthropic(t, func(w http.ResponseWriter, r *http.Request) {
+		got = decodeAnthropicRequest(t, r)
+		w.Header().Set("Content-Type", "text/event-stream")
+		for _, event := range events {
+			fmt.Fprint(w, event+"\n\n")
+			w.(http.Flusher).Flush()
+		}
+	})
+
+	var updates []StreamUpdate
+	candidates, err := provider.GenerateStream(context.Background(), UserMessages("diff"), func(update StreamUpdate) {
+		updates = append(updates, update)
+	})
+	if err != nil {
+		t.Fatalf("GenerateStream: %v", err)
+	}
+
+	if !got.Stream || got.System != systemPrompt(false) {
+		t.Errorf("stream = %v, system set = %v; want a streaming request with the top-level system prompt", got.Stream, got.System != "")
+	}
+	want := []string{"feat(api): add endpoint", "fix(db): close rows"}
+	if !reflect.DeepEqual(candidates, want) {
+		t.Errorf("candidates = %q, want %q", candidates, want)
+	}
+	if len(updates) == 0 {
+		t.Error("onUpdate was never called")
+	}
+}
+
+func TestAnthropicGenerateStreamError(t *testing.T) {
+	provider := newTestAnthropic(t, func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "text/event-stream")
+		fmt.Fprint(w, "event: error\n"+`data: {"type":"error","error":{"type":"overloaded_error","message":"Overloaded"}}`+"\n\n")
+	})
+
+	_, err := provider.GenerateStream(context.Background(), UserMessages("diff"), func(StreamUpdate) {})
+
+	var apiErr *APIError
+	if !errors.As(err, &apiErr) {
+		t.Fatalf("err = %v, want an APIError", err)
+	}
+	if apiErr.StatusCode != http.StatusServiceUnavailable || apiErr.Message != "overloaded_error: Overloaded" {
+		t.Errorf("APIError = %+v, want status 503 with the stream error message", apiErr)
+	}
+	if retryable, _ := classifyError(err); !retryable {
+		t.Error("a stream overload error should be retryable")
+	}
+}
+
+func TestAnthropicAPIError(t *testing.T) {
+	tests := []struct {
+		name        string
+		status      int
+		retryAfter  string
+		body        string
+		wantMessage string
+		wantRetry   func(time.Duration) bool
+	}{
+		{
+			name:        "rate limit with seconds",
+			status:      http.StatusTooManyRequests,
+			retryAfter:  "7",
+			body:        `{"type":"error","error":{"type":"rate_limit_error","message":"Number of requests has exceeded your rate limit"}}`,
+			wantMessage: "rate_limit_error: Number of requests has exceeded your rate limit",
+			wantRetry:   func(d time.Duration) bool { return d == 7*time.Second },
+		},
+		{
+			name:        "overloaded with HTTP date",
+			status:      529,
+			retryAfter:  time.Now().Add(time.Minute).UTC().Format(http.TimeFormat),
+			body:        `{"type":"error","error":{"type":"overloaded_error","message":"Overloaded"}}`,
+			wantMessage: "overloaded_error: Overloaded",
+			wantRetry:   func(d time.Duration) bool { return d > 55*time.Second && d <= time.Minute },
+		},
+		{
+			name:        "invalid key without Retry-After",
+			status:      http.StatusUnauthorized,
+			body:        `{"type":"error","error":{"type":"authentication_error","message":"invalid x-api-key"}}`,
+			wantMessage: "authentication_error: invalid x-api-key",
+			wantRetry:   func(d time.Duration) bool { return d == 0 },
+		},
+		{
+			name:        "plain text body",
+			status:      http.StatusBadGateway,
+			body:        "upstream unavailable\n",
+			wantMessage: "upstream unavailable",
+			wantRetry:   func(d time.Duration) bool { return d == 0 },
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			provider := newTestAnthropic(t, func(w http.ResponseWriter, r *http.Request) {
+				if tt.retryAfter != "" {
+					w.Header().Set("Retry-After", tt.retryAfter)
+				}
+				w.WriteHeader(tt.status)
+				fmt.Fprint(w, tt.body)
+			})
+
+			_, err := provider.Generate(context.Background(), UserMessages("diff"))
+
+			var apiErr *APIError
+			if !errors.As(err, &apiErr) {
+				t.Fatalf("err = %v, want an APIError", err)
+			}
+			if apiErr.Provider != "anthropic" || apiErr.StatusCode != tt.status {
+				t.Errorf("provider = %q, status = %d; want anthropic, %d", apiErr.Provider, apiErr.StatusCode, tt.status)
+			}
+			if apiErr.Message != tt.wantMessage {
+				t.Errorf("message = %q, want %q", apiErr.Message, tt.wantMessage)
+			}
+			if !tt.wantRetry(apiErr.RetryAfter) {
+				t.Errorf("RetryAfter = %s", apiErr.RetryAfter)
+			}
+		})
+	}
+}
@@ -0,0 +1,381 @@
+package llm
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"regexp"
+	"strings"
+)
+
+// Candidate는 JSON 모드에서 모델이 반환하는 커밋 메시지 후보 하나입니다.
+type Candidate struct {
+	Type    string   `json:"type"`    // Conventional Commit 타입 (feat, fix 등, 호환성을 깨는 변경이면 "feat!")
+	Scope   string   `json:"scope"`   // scope (없으면 빈 문자열)
+	Subject string   `json:"subject"` // 제목
+	Body    string   `json:"body"`    // 본문 (없으면 빈 문자열)
+	Footers []string `json:"footers"` // 푸터 (예: "Refs: #123")
+}
+
+// String은 후보를 커밋 메시지 형식으로 변환합니다.
+// 제목 줄, 빈 줄, 본문, 빈 줄, 푸터 순서이며 비어 있는 부분은 생략합니다.
+func (c Candidate) String() string {
+	var builder strings.Builder
+
+	subject := strings.TrimSpace(c.Subject)
+	switch {
+	case c.Type != "" && c.Scope != "":
+		// "feat!" 타입의 "!"는 scope 뒤에 붙임 (feat(api)!: ...)
+		commitType, breaking := strings.CutSuffix(c.Type, "!")
+		header := commitType + "(" + c.Scope + ")"
+		if breaking {
+			header += "!"
+		}
+		builder.WriteString(header + ": " + subject)
+	case c.Type != "":
+		builder.WriteString(c.Type + ": " + subject)
+	default:
+		builder.WriteString(subject)
+	}
+
+	if body := strings.Trim(c.Body, "\n"); strings.TrimSpace(body) != "" {
+		builder.WriteString("\n\n" + body)
+	}
+
+	var footers []string
+	for _, footer := range c.Footers {
+		if footer = strings.TrimSpace(footer); footer != "" {
+			footers = append(footers, footer)
+		}
+	}
+	if len(footers) > 0 {
+		builder.WriteString("\n\n" + strings.Join(footers, "\n"))
+	}
+
+	return builder.String()
+}
+
+// candidatesSchema는 JSON 모드 응답의 JSON Schema입니다.
+var candidatesSchema = json.RawMessage(`{
+  "type": "object",
+  "properties": {
+    "candidates": {
+      "type": "array",
+      "items": {
+        "type": "object",
+        "properties": {
+          "type": {"type": "string"},
+          "scope": {"type": "string"},
+          "subject": {"type": "string"},
+          "body": {"type": "string"},
+          "footers": {"type": "array", "items": {"type": "string"}}
+        },
+        "required": ["type", "scope", "subject", "body", "footers"],
+        "additionalProperties": false
+      }
+    }
+  },
+  "required": ["candidates"],
+  "additionalProperties": false
+}`)
+
+// jsonSystemMessage는 JSON 모드에서 systemMessage 뒤에 붙는 출력 형식 지시문입니다.
+const jsonSystemMessage = `
+
+OUTPUT FORMAT:
+Respond with a single JSON object and nothing else, in this shape:
+{"candidates":[{"type":"feat","scope":"auth","subject":"add login endpoint","body":"","footers":[]}]}
+- Put each candidate in the "candidates" array instead of numbering them
+- "scope" is an empty string when there is no scope
+- For a breaking change, end "type" with "!" (e.g. "feat!")
+- Multi-line details go in "body" (lines separated by \n, no leading number); "body" is empty when not requested
+- "footers" holds trailer lines such as "BREAKING CHANGE: ..." or "Refs: #123"`
+
+// textSystemMessage는 텍스트 모드에서 systemMessage 뒤에 붙는 출력 형식 지시문입니다.
+// JSON 모드용 프롬프트를 받은 뒤 백엔드가 JSON 형식을 거부해 텍스트로 대체할 때도 번호 형식을 유지합니다.
+const textSystemMessage = `
+
+OUTPUT FORMAT:
+Number every candidate ("1) type(scope): subject"), put any body lines right after its subject line,
+and separate candidates with a blank line. Do not add any other text before or after the candidates.`
+
+// systemPrompt는 JSON 모드 여부에 맞는 system 지시문을 반환합니다.
+func systemPrompt(jsonMode bool) string {
+	if jsonMode {
+		return systemMessage + jsonSystemMessage
+	}
+	return systemMessage + textSystemMessage
+}
+
+// candidateHeaderPattern은 커밋 메시지 제목의 type(scope)!: subject 형식입니다.
+var candidateHeaderPattern = regexp.MustCompile(`^([a-z]+)(?:\(([^()]*)\))?(!)?: (.*)$`)
+
+// footerLinePattern은 "Refs: #12", "BREAKING CHANGE: ..." 같은 footer 줄입니다.
+var footerLinePattern = regexp.MustCompile(`^(?:[A-Za-z][A-Za-z0-9-]*|BREAKING CHANGE): \S`)
+
+// ParseCandidate는 커밋 메시지를 JSON 모드의 후보 형식으로 나눕니다.
+// 마지막 문단의 모든 줄이 footer이면 footers로 분리합니다.
+func ParseCandidate(message string) Candidate {
+	message = strings.Trim(strings.ReplaceAll(message, "\r\n", "\n"), "\n")
+	subject, body, _ := strings.Cut(message, "\n")
+
+	var candidate Candidate
+	if match := candidateHeaderPattern.FindStringSubmatch(subject); match != nil {
+		candidate.Type = match[1] + match[3]
+		candidate.Scope = match[2]
+		candidate.Subject = match[4]
+	} else {
+		candidate.Subject = subject
+	}
+
+	paragraphs := strings.Split(strings.Trim(body, "\n"), "\n\n")
+	if last := paragraphs[len(paragraphs)-1]; last != "" {
+		footers := strings.Split(last, "\n")
+		isFooter := true
+		for _, line := range footers {
+			if !footerLinePattern.MatchString(line) {
+				isFooter = false
+				break
+			}
+		}
+		if isFooter {
+			candidate.Footers = footers
+			paragraphs = paragraphs[:len(paragraphs)-1]
+		}
+	}
+	candidate.Body = strings.Join(paragraphs, "\n\n")
+	return candidate
+}
+
+// FormatCandidatesJSON은 커밋 메시지들을 JSON 모드의 응답 형식({"candidates": [...]})으로 변환합니다.
+// 재추천 등에서 이전 후보를 assistant 메시지로 전달할 때 사용합니다.
+func FormatCandidatesJSON(messages []string) string {
+	candidates := make([]Candidate, len(messages))
+	for i, message := range messages {
+		candidates[i] = ParseCandidate(message)
+		if candidates[i].Footers == nil {
+			candidates[i].Footers = []string{}
+		}
+	}
+
+	data, _ := json.Marshal(struct {
+		Candidates []Candidate `json:"candidates"`
+	}{candidates})
+	return string(data)
+}
+
+// parseOutput은 응답 텍스트를 후보 목록으로 변환합니다.
+// JSON 모드에서 JSON 파싱에 실패하면 번호 형식 텍스트 파서로 대체합니다.
+func parseOutput(text string, jsonMode bool) []string {
+	if jsonMode {
+		if candidates, err := parseCandidatesJSON(text); err == nil {
+			return renderCandidates(candidates)
+		}
+	}
+	return parseResponse(text)
+}
+
+// parseCandidatesJSON은 JSON 응답에서 후보들을 추출합니다.
+// 앞뒤의 설명이나 markdown 코드 블록은 무시하며, {"candidates": [...]}와 [...] 형식을 모두 허용합니다.
+func parseCandidatesJSON(text string) ([]Candidate, error) {
+	start := strings.IndexAny(text, "{[")
+	end := strings.LastIndexAny(text, "}]")
+	if start < 0 || end < start {
+		return nil, errors.New("no JSON found in response")
+	}
+	data := []byte(text[start : end+1])
+
+	var parsed []Candidate
+	if data[0] == '[' {
+		if err := json.Unmarshal(data, &parsed); err != nil {
+			return nil, err
+		}
+	} else {
+		var resp struct {
+			Candidates []Candidate `json:"candidates"`
+		}
+		if err := json.Unmarshal(data, &resp); err != nil {
+			return nil, err
+		}
+		parsed = resp.Candidates
+	}
+
+	var candidates []Candidate
+	for _, candidate := range parsed {
+		if strings.TrimSpace(candidate.Subject) != "" {
+			candidates = append(candidates, candidate)
+		}
+	}
+	if len(candidates) == 0 {
+		return nil, errors.New("no candidates in JSON response")
+	}
+
+	return candidates, nil
+}
+
+// renderCandidates는 후보들을 커밋 메시지 문자열로 변환합니다.
+func renderCandidates(candidates []Candidate) []string {
+	messages := make([]string, len(candidates))
+	for i, candidate := range candidates {
+		messages[i] = candidate.String()
+	}
+	return messages
+}
+
+// 백엔드가 JSON 출력 형식 옵션을 거부했음을 나타내는 에러 메시지
+var (
+	// OpenAI 호환 API: response_format 옵션이나 json_schema/json_object 형식을 지원하지 않음
+	responseFormatErrorPattern = regexp.MustCompile(`(?i)response_format|json_schema|json_object`)
+	// Ollama: format 필드를 해석하지 못함 (구버전의 "ChatRequest.format", "invalid format", "invalid JSON schema in format" 등)
+	ollamaFormatErrorPattern = regexp.MustCompile(`(?i)\.format\b|\binvalid format\b|\bin format\b|\bformat must\b`)
+)
+
+// isUnsupportedFormatError는 err가 pattern에 맞는 메시지의 400/422 응답인지 확인합니다.
+// 요청 본문 오류 등 다른 400 에러는 JSON 모드를 끄지 않고 그대로 반환하도록 구체적인 메시지만 확인합니다.
+func isUnsupportedFormatError(err error, pattern *regexp.Regexp) bool {
+	var apiErr *APIError
+	if !errors.As(err, &apiErr) {
+		return false
+	}
+	if apiErr.StatusCode != http.StatusBadRequest && apiErr.StatusCode != http.StatusUnprocessableEntity {
+		return false
+	}
+	return pattern.MatchString(apiErr.Message)
+}
+
+// candidateScanner는 스트리밍 중인 JSON 응답을 조각 단위로 받아
+// 후보 객체가 닫히는 즉시 파싱합니다. 문자열 안의 괄호는 무시합니다.
+type candidateScanner struct {
+	buf        []byte
+	started    bool // 첫 번째 '{' 또는 '['를 만났는지 여부
+	candDepth  int  // 후보 객체가 위치하는 깊이
+	depth      int
+	inString   bool
+	escaped    bool
+	objStart   int // 작성 중인 후보 객체의 시작 위치 (-1이면 없음)
+	candidates []Candidate
+}
+
+// newCandidateScanner는 새로운 candidateScanner를 생성합니다.
+func newCandidateScanner() *candidateScanner {
+	return &candidateScanner{objStart: -1}
+}
+
+// Write는 응답 조각을 추가하고 완성된 후보 객체를 파싱합니다.
+func (s *candidateScanner) Write(chunk string) {
+	for i := 0; i < len(chunk); i++ {
+		c := chunk[i]
+
+		if !s.started {
+			// JSON 시작 전의 설명이나 코드 블록 표시는 무시
+			if c != '{' && c != '[' {
+				continue
+			}
+			s.started = true
+			// {"candidates": [{...}]} 이면 깊이 3, [{...}] 이면 깊이 2
+			s.candDepth = 3
+			if c == '[' {
+				s.candDepth = 2
+			}
+		}
+
+		s.buf = append(s.buf, c)
+
+		if s.inString {
+			switch {
+			case s.escaped:
+				s.escaped = false
+			case c == '\\':
+				s.escaped = true
+			case c == '"':
+				s.inString = false
+			}
+			continue
+		}
+
+		switch c {
+		case '"':
+			s.inString = true
+		case '{', '[':
+			s.depth++
+			if c == '{' && s.depth == s.candDepth {
+				s.objStart = len(s.buf) - 1
+			}
+		case '}', ']':
+			if c == '}' && s.depth == s.candDepth && s.objStart >= 0 {
+				var candidate Candidate
+				if err := json.Unmarshal(s.buf[s.objStart:], &candidate); err == nil && strings.TrimSpace(candidate.Subject) != "" {
+					s.candidates = append(s.candidates, candidate)
+				}
+				s.objStart = -1
+			}
+			s.depth--
+		}
+	}
+}
+
+// Started는 JSON 응답이 시작되었는지 반환합니다.
+func (s *candidateScanner) Started() bool {
+	return s.started
+}
+
+// Snapshot은 지금까지 완성된 후보들과 작성 중인 후보 텍스트를 반환합니다.
+func (s *candidateScanner) Snapshot() (messages []string, partial string) {
+	messages = renderCandidates(s.candidates)
+	if s.objStart < 0 {
+		return messages, ""
+	}
+
+	obj := string(s.buf[s.objStart:])
+	current := Candidate{
+		Type:    partialField(obj, "type"),
+		Scope:   partialField(obj, "scope"),
+		Subject: partialField(obj, "subject"),
+		Body:    partialField(obj, "body"),
+	}
+	if current.Subject == "" {
+		return messages, ""
+	}
+	return messages, current.String()
+}
+
+// partialField는 작성 중인 JSON 객체에서 문자열 필드의 현재까지 값을 추출합니다.
+func partialField(obj, key string) string {
+	idx := strings.Index(obj, `"`+key+`"`)
+	if idx < 0 {
+		return ""
+	}
+	rest := strings.TrimLeft(obj[idx+len(key)+2:], " \t\r\n")
+	if !strings.HasPrefix(rest, ":") {
+		return ""
+	}
+	rest = strings.TrimLeft(rest[1:], " \t\r\n")
+	if !strings.HasPrefix(rest, `"`) {
+		return ""
+	}
+
+	var value strings.Builder
+	for i := 1; i < len(rest); i++ {
+		c := rest[i]
+		if c == '"' {
+			break
+		}
+		if c == '\\' {
+			if i+1 >= len(rest) {
+				break
+			}
+			i++
+			switch rest[i] {
+			case 'n':
+				value.WriteByte('\n')
+			case 't':
+				value.WriteByte('\t')
+			default:
+				value.WriteByte(rest[i])
+			}
+			continue
+		}
+		value.WriteByte(c)
+	}
+	return value.String()
+}
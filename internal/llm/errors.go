@@ -0,0 +1,72 @@
+package llm
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// APIError는 제공자 API가 반환한 HTTP 에러를 나타냅니다.
+type APIError struct {
+	Provider   string        // 제공자 이름
+	StatusCode int           // HTTP 상태 코드
+	Message    string        // 에러 메시지
+	RetryAfter time.Duration // Retry-After 헤더 값 (없으면 0)
+}
+
+func (e *APIError) Error() string {
+	return fmt.Sprintf("%s API error (status %d): %s", e.Provider, e.StatusCode, e.Message)
+}
+
+// newAPIError는 HTTP 에러 응답을 APIError로 변환합니다.
+// 본문이 {"error": {"type": ..., "message": ...}} 형식이면 메시지를 추출합니다.
+func newAPIError(provider string, resp *http.Response, data []byte) *APIError {
+	message := strings.TrimSpace(string(data))
+
+	var errResp struct {
+		Error struct {
+			Type    string `json:"type"`
+			Message string `json:"message"`
+		} `json:"error"`
+	}
+	if err := json.Unmarshal(data, &errResp); err == nil && errResp.Error.Message != "" {
+		message = errResp.Error.Message
+		if errResp.Error.Type != "" {
+			message = errResp.Error.Type + ": " + message
+		}
+	}
+
+	if message == "" {
+		message = resp.Status
+	}
+
+	return &APIError{
+		Provider:   provider,
+		StatusCode: resp.StatusCode,
+		Message:    message,
+		RetryAfter: parseRetryAfter(resp.Header.Get("Retry-After")),
+	}
+}
+
+// parseRetryAfter는 Retry-After 헤더(초 또는 HTTP-date)를 duration으로 변환합니다.
+func parseRetryAfter(value string) time.Duration {
+	value = strings.TrimSpace(value)
+	if value == "" {
+		return 0
+	}
+
+	if seconds, err := strconv.ParseFloat(value, 64); err == nil && seconds > 0 {
+		return time.Duration(seconds * float64(time.Second))
+	}
+
+	if date, err := http.ParseTime(value); err == nil {
+		if wait := time.Until(date); wait > 0 {
+			return wait
+		}
+	}
+
+	return 0
+}
@@ -0,0 +1,180 @@
+package llm
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net"
+	"net/http"
+	"sync"
+)
+
+// FallbackEntry는 폴백 체인에 포함된 제공자입니다.
+type FallbackEntry struct {
+	Name     string   // 출력용 제공자 이름
+	Provider Provider // 실제 제공자 (보통 RetryProvider로 감싼 상태)
+}
+
+// FallbackProvider는 여러 제공자를 순서대로 시도합니다.
+// 네트워크, 쿼터(429), 인증(401/403), 서버(5xx) 에러로 실패하면 다음 제공자로 넘어가고,
+// 그 밖의 에러나 ctx 취소는 즉시 반환합니다.
+// 요약(GenerateText)은 여러 goroutine에서 동시에 호출할 수 있습니다.
+type FallbackProvider struct {
+	entries []FallbackEntry
+	logf    func(format string, args ...any)
+
+	mu   sync.Mutex
+	used string // 마지막으로 후보를 생성한 제공자 (mu로 보호)
+}
+
+// NewFallbackProvider는 새로운 FallbackProvider 인스턴스를 생성합니다.
+// logf가 nil이 아니면 다음 제공자로 넘어갈 때마다 호출됩니다.
+func NewFallbackProvider(entries []FallbackEntry, logf func(format string, args ...any)) *FallbackProvider {
+	return &FallbackProvider{
+		entries: entries,
+		logf:    logf,
+	}
+}
+
+// Generate는 성공할 때까지 제공자를 순서대로 호출합니다.
+func (f *FallbackProvider) Generate(ctx context.Context, messages []Message) ([]string, error) {
+	candidates, name, err := fallbackCall(ctx, f, func(provider Provider) ([]string, error) {
+		return provider.Generate(ctx, messages)
+	}, nil)
+	f.setUsed(name)
+	return candidates, err
+}
+
+// GenerateStream은 성공할 때까지 제공자를 순서대로 스트리밍으로 호출합니다.
+// 이미 일부 응답이 전달된 뒤의 실패는 다음 제공자로 넘기지 않습니다.
+func (f *FallbackProvider) GenerateStream(ctx context.Context, messages []Message, onUpdate StreamFunc) ([]string, error) {
+	streamed := false
+	candidates, name, err := fallbackCall(ctx, f, func(provider Provider) ([]string, error) {
+		return GenerateStream(ctx, provider, messages, func(update StreamUpdate) {
+			streamed = true
+			onUpdate(update)
+		})
+	}, func() bool { return !streamed })
+	f.setUsed(name)
+	return candidates, err
+}
+
+// GenerateText는 성공할 때까지 제공자를 순서대로 GenerateText로 호출합니다.
+// 텍스트 생성을 지원하지 않는 제공자는 건너뜁니다.
+// 보조 호출이므로 Used가 반환하는 후보 생성 제공자는 바꾸지 않습니다.
+func (f *FallbackProvider) GenerateText(ctx context.Context, system, prompt string) (string, error) {
+	text, _, err := fallbackCall(ctx, f, func(provider Provider) (string, error) {
+		generator, ok := provider.(TextGenerator)
+		if !ok {
+			return "", errTextUnsupported
+		}
+		return generator.GenerateText(ctx, system, prompt)
+	}, nil)
+	return text, err
+}
+
+// JSONMode는 체인의 모든 제공자가 JSON 형식 응답을 요청하는지 반환합니다.
+// 프롬프트는 모든 제공자에 같이 전달되므로, 하나라도 텍스트 모드로 바뀌면 번호 형식을 사용합니다.
+func (f *FallbackProvider) JSONMode() bool {
+	for _, entry := range f.entries {
+		if !JSONMode(entry.Provider) {
+			return false
+		}
+	}
+	return len(f.entries) > 0
+}
+
+// fallbackCall은 call이 성공할 때까지 f의 제공자를 순서대로 호출하고, 성공한 제공자 이름을 함께 반환합니다.
+// canFallback이 nil이 아니면 다음 제공자로 넘어가기 전에 추가로 확인합니다.
+func fallbackCall[T any](ctx context.Context, f *FallbackProvider, call func(Provider) (T, error), canFallback func() bool) (T, string, error) {
+	var zero T
+	if len(f.entries) == 0 {
+		return zero, "", fmt.Errorf("no providers configured")
+	}
+
+	var errs []error
+	for i, entry := range f.entries {
+		result, err := call(entry.Provider)
+		if err == nil {
+			return result, entry.Name, nil
+		}
+
+		if ctx.Err() != nil || !shouldFallback(err) || (canFallback != nil && !canFallback()) {
+			return zero, "", err
+		}
+
+		errs = append(errs, fmt.Errorf("%s: %w", entry.Name, err))
+
+		if i+1 < len(f.entries) && f.logf != nil {
+			f.logf("%s failed (%v), falling back to %s", entry.Name, err, f.entries[i+1].Name)
+		}
+	}
+
+	if len(errs) == 1 {
+		return zero, "", errs[0]
+	}
+	return zero, "", fmt.Errorf("all providers failed:\n%w", errors.Join(errs...))
+}
+
+// setUsed는 후보 생성에 성공한 제공자 이름을 기록합니다 (실패한 호출이면 name이 비어 있어 무시).
+func (f *FallbackProvider) setUsed(name string) {
+	if name == "" {
+		return
+	}
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.used = name
+}
+
+// Used는 마지막으로 후보 생성에 성공한 제공자 이름을 반환합니다.
+func (f *FallbackProvider) Used() string {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	return f.used
+}
+
+// Names는 체인에 포함된 제공자 이름들을 순서대로 반환합니다.
+func (f *FallbackProvider) Names() []string {
+	names := make([]string, len(f.entries))
+	for i, entry := range f.entries {
+		names[i] = entry.Name
+	}
+	return names
+}
+
+// Close는 모든 제공자를 닫습니다.
+func (f *FallbackProvider) Close() error {
+	var errs []error
+	for _, entry := range f.entries {
+		if err := entry.Provider.Close(); err != nil {
+			errs = append(errs, err)
+		}
+	}
+	return errors.Join(errs...)
+}
+
+// shouldFallback은 다른 제공자로 넘어가면 성공할 가능성이 있는 에러인지 확인합니다.
+func shouldFallback(err error) bool {
+	var apiErr *APIError
+	if errors.As(err, &apiErr) {
+		switch {
+		case apiErr.StatusCode == http.StatusUnauthorized,
+			apiErr.StatusCode == http.StatusForbidden,
+			apiErr.StatusCode == http.StatusPaymentRequired,
+			apiErr.StatusCode == http.StatusNotFound,
+			apiErr.StatusCode == http.StatusRequestTimeout,
+			apiErr.StatusCode == http.StatusTooManyRequests,
+			apiErr.StatusCode >= http.StatusInternalServerError:
+			return true
+		default:
+			return false
+		}
+	}
+
+	if errors.Is(err, errTextUnsupported) {
+		return true
+	}
+
+	var netErr net.Error
+	return errors.As(err, &netErr)
+}
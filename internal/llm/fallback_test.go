@@ -0,0 +1,88 @@
+package llm
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"sync"
+	"testing"
+)
+
+// fakeProvider는 고정된 응답이나 에러를 반환하는 테스트용 제공자입니다.
+type fakeProvider struct {
+	candidates []string
+	text       string
+	err        error
+}
+
+func (p *fakeProvider) Generate(ctx context.Context, messages []Message) ([]string, error) {
+	return p.candidates, p.err
+}
+
+func (p *fakeProvider) GenerateText(ctx context.Context, system, prompt string) (string, error) {
+	return p.text, p.err
+}
+
+func (p *fakeProvider) Close() error {
+	return nil
+}
+
+func TestFallbackGenerateTextConcurrent(t *testing.T) {
+	quota := &APIError{Provider: "groq", StatusCode: http.StatusTooManyRequests, Message: "quota exceeded"}
+	fallback := NewFallbackProvider([]FallbackEntry{
+		{Name: "groq", Provider: &fakeProvider{err: quota}},
+		{Name: "ollama", Provider: &fakeProvider{candidates: []string{"feat: add x"}, text: "summary"}},
+	}, nil)
+
+	if _, err := fallback.Generate(context.Background(), UserMessages("diff")); err != nil {
+		t.Fatalf("Generate: %v", err)
+	}
+
+	// core.Summarize처럼 여러 goroutine에서 동시에 요약 호출
+	var wg sync.WaitGroup
+	errs := make(chan error, 16)
+	for i := 0; i < 16; i++ {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			text, err := fallback.GenerateText(context.Background(), "summarize", fmt.Sprintf("group %d", i))
+			if err == nil && text != "summary" {
+				err = fmt.Errorf("text = %q, want %q", text, "summary")
+			}
+			if err != nil {
+				errs <- err
+			}
+			fallback.Used()
+		}(i)
+	}
+	wg.Wait()
+	close(errs)
+
+	for err := range errs {
+		t.Errorf("GenerateText: %v", err)
+	}
+	if got := fallback.Used(); got != "ollama" {
+		t.Errorf("Used() = %q, want %q", got, "ollama")
+	}
+}
+
+func TestFallbackUsedIgnoresGenerateText(t *testing.T) {
+	primary := &fakeProvider{candidates: []string{"feat: add x"}}
+	fallback := NewFallbackProvider([]FallbackEntry{
+		{Name: "groq", Provider: primary},
+		{Name: "ollama", Provider: &fakeProvider{text: "summary"}},
+	}, nil)
+
+	if _, err := fallback.Generate(context.Background(), UserMessages("diff")); err != nil {
+		t.Fatalf("Generate: %v", err)
+	}
+
+	// 요약만 다음 제공자로 넘어가도 후보를 생성한 제공자는 그대로 유지
+	primary.err = &APIError{Provider: "groq", StatusCode: http.StatusServiceUnavailable, Message: "unavailable"}
+	if _, err := fallback.GenerateText(context.Background(), "summarize", "diff"); err != nil {
+		t.Fatalf("GenerateText: %v", err)
+	}
+	if got := fallback.Used(); got != "groq" {
+		t.Errorf("Used() = %q, want %q", got, "groq")
+	}
+}
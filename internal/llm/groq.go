@@ -1,93 +1,33 @@
 package llm
 
 import (
-	"context"
 	"fmt"
 	"os"
 
 	"github.com/sashabaranov/go-openai"
 )
 
+// Groq 제공자의 기본값
+const (
+	groqDefaultBaseURL = "https://api.groq.com/openai/v1"
+	groqDefaultModel   = "llama-3.3-70b-versatile"
+)
+
 // GroqProvider는 Groq API를 사용하는 제공자입니다.
-// Groq는 OpenAI 호환 API를 제공하므로 OpenAI SDK를 사용합니다.
+// Groq는 OpenAI 호환 API를 제공하므로 OpenAIProvider를 그대로 사용합니다.
+// JSON Schema는 일부 모델만 지원하므로 JSON 모드에서는 json_object 형식을 요청합니다.
 type GroqProvider struct {
-	client *openai.Client
-	model  string
+	*OpenAIProvider
 }
 
 // NewGroqProvider는 새로운 GroqProvider 인스턴스를 생성합니다.
-func NewGroqProvider(apiKey string) (*GroqProvider, error) {
-	config := openai.DefaultConfig(apiKey)
-	config.BaseURL = "https://api.groq.com/openai/v1"
-
-	client := openai.NewClientWithConfig(config)
-
-	return &GroqProvider{
-		client: client,
-		model:  "llama-3.3-70b-versatile",
-	}, nil
-}
-
-// Generate는 Groq API를 호출하여 커밋 메시지 후보들을 생성합니다.
-func (g *GroqProvider) Generate(prompt string) ([]string, error) {
-	ctx := context.Background()
-
-	// System message로 강력한 지시 제공
-	systemMessage := `You are a commit message generator that strictly follows instructions.
-
-CRITICAL RULES:
-1. When asked for multi-line format, you MUST provide multi-line commit messages
-2. Multi-line format structure:
-   - Title line (type(scope): summary)
-   - Blank line
-   - Detailed bullet points with proper indentation
-3. Follow the exact format shown in examples
-4. Preserve indentation and blank lines exactly as instructed
-
-Always follow the detail level instructions in the user prompt precisely.`
-
-	resp, err := g.client.CreateChatCompletion(
-		ctx,
-		openai.ChatCompletionRequest{
-			Model: g.model,
-			Messages: []openai.ChatCompletionMessage{
-				{
-					Role:    openai.ChatMessageRoleSystem,
-					Content: systemMessage,
-				},
-				{
-					Role:    openai.ChatMessageRoleUser,
-					Content: prompt,
-				},
-			},
-			Temperature: 0.5, // 낮춰서 더 일관된 응답 유도 (0.7 → 0.5)
-			MaxTokens:   4096,
-		},
-	)
-
+func NewGroqProvider(opts Options) (*GroqProvider, error) {
+	provider, err := newOpenAICompatible("groq", opts.withDefaults(groqDefaultBaseURL, groqDefaultModel), openai.ChatCompletionResponseFormatTypeJSONObject)
 	if err != nil {
-		return nil, fmt.Errorf("failed to generate completion: %w", err)
-	}
-
-	if len(resp.Choices) == 0 {
-		return nil, fmt.Errorf("no choices in response")
+		return nil, err
 	}
 
-	text := resp.Choices[0].Message.Content
-
-	messages := parseCommitMessages(text)
-
-	if len(messages) == 0 {
-		return []string{text}, nil
-	}
-
-	return messages, nil
-}
-
-// Close는 Groq 클라이언트를 닫습니다.
-func (g *GroqProvider) Close() error {
-	// openai.Client에는 Close 메서드가 없음
-	return nil
+	return &GroqProvider{OpenAIProvider: provider}, nil
 }
 
 // NewGroqProviderFromEnv는 환경변수에서 API 키를 읽어 GroqProvider를 생성합니다.
@@ -97,5 +37,5 @@ func NewGroqProviderFromEnv() (*GroqProvider, error) {
 		return nil, fmt.Errorf("AI_COMMIT_GROQ_API_KEY environment variable not set")
 	}
 
-	return NewGroqProvider(apiKey)
+	return NewGroqProvider(Options{APIKey: apiKey})
 }
@@ -0,0 +1,242 @@
+package llm
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+)
+
+// Ollama 제공자의 기본값
+const (
+	ollamaDefaultHost  = "http://localhost:11434"
+	ollamaDefaultModel = "llama3.2"
+)
+
+// OllamaProvider는 로컬 Ollama 서버의 /api/chat 엔드포인트를 사용하는 제공자입니다.
+// API 키가 필요 없으므로 외부 네트워크가 없는 환경에서도 사용할 수 있습니다.
+type OllamaProvider struct {
+	httpClient  *http.Client
+	baseURL     string
+	model       string
+	temperature float32
+	maxTokens   int
+	logf        func(format string, args ...any)
+
+	// JSON 모드에서 요청할 응답 형식 (nil이면 텍스트)
+	format json.RawMessage
+}
+
+// ollamaRequest는 /api/chat 요청 본문입니다.
+type ollamaRequest struct {
+	Model    string          `json:"model"`
+	Messages []ollamaMessage `json:"messages"`
+	Stream   bool            `json:"stream"`
+	Format   json.RawMessage `json:"format,omitempty"`
+	Options  ollamaOptions   `json:"options"`
+}
+
+type ollamaMessage struct {
+	Role    string `json:"role"`
+	Content string `json:"content"`
+}
+
+type ollamaOptions struct {
+	Temperature float32 `json:"temperature"`
+	NumPredict  int     `json:"num_predict"`
+}
+
+// ollamaResponse는 /api/chat 응답 본문입니다.
+type ollamaResponse struct {
+	Message ollamaMessage `json:"message"`
+	Done    bool          `json:"done"`
+	Error   string        `json:"error"`
+}
+
+// NewOllamaProvider는 새로운 OllamaProvider 인스턴스를 생성합니다.
+// JSON 모드에서는 format 필드에 JSON Schema를 지정합니다 (structured outputs).
+func NewOllamaProvider(opts Options) (*OllamaProvider, error) {
+	opts = opts.withDefaults(ollamaDefaultHost, ollamaDefaultModel)
+
+	provider := &OllamaProvider{
+		httpClient:  &http.Client{},
+		baseURL:     strings.TrimRight(opts.BaseURL, "/"),
+		model:       opts.Model,
+		temperature: opts.Temperature,
+		maxTokens:   opts.MaxTokens,
+		logf:        opts.Logf,
+	}
+	if opts.JSONMode {
+		provider.format = candidatesSchema
+	}
+
+	return provider, nil
+}
+
+// Generate는 Ollama chat API를 호출하여 커밋 메시지 후보들을 생성합니다.
+// 서버가 format 옵션을 거부하면 (구버전 등) 텍스트 모드로 한 번 다시 요청합니다.
+func (p *OllamaProvider) Generate(ctx context.Context, messages []Message) ([]string, error) {
+	candidates, err := p.generate(ctx, messages)
+	if err != nil && p.disableJSON(err) {
+		return p.generate(ctx, messages)
+	}
+	return candidates, err
+}
+
+// generate는 Ollama chat API를 한 번 호출합니다.
+func (p *OllamaProvider) generate(ctx context.Context, messages []Message) ([]string, error) {
+	text, err := p.complete(ctx, systemPrompt(p.format != nil), messages, p.format)
+	if err != nil {
+		return nil, err
+	}
+
+	return parseOutput(text, p.format != nil), nil
+}
+
+// GenerateText는 format 옵션 없이 Ollama chat API를 호출해 텍스트를 그대로 반환합니다.
+func (p *OllamaProvider) GenerateText(ctx context.Context, system, prompt string) (string, error) {
+	return p.complete(ctx, system, UserMessages(prompt), nil)
+}
+
+// complete는 스트리밍 없이 chat API를 호출하고 응답 메시지를 반환합니다.
+func (p *OllamaProvider) complete(ctx context.Context, system string, messages []Message, format json.RawMessage) (string, error) {
+	resp, err := p.send(ctx, system, messages, format, false)
+	if err != nil {
+		return "", err
+	}
+	defer resp.Body.Close()
+
+	var result ollamaResponse
+	if err := json.NewDecoder(resp.Body).Decode(&result); err != nil {
+		return "", fmt.Errorf("failed to decode response: %w", err)
+	}
+
+	if result.Message.Content == "" {
+		return "", fmt.Errorf("empty response from Ollama")
+	}
+
+	return result.Message.Content, nil
+}
+
+// GenerateStream은 Ollama의 NDJSON 스트림으로 후보를 생성하며 진행 상황을 전달합니다.
+// 서버가 format 옵션을 거부하면 텍스트 모드로 한 번 다시 요청합니다.
+func (p *OllamaProvider) GenerateStream(ctx context.Context, messages []Message, onUpdate StreamFunc) ([]string, error) {
+	candidates, err := p.generateStream(ctx, messages, onUpdate)
+	if err != nil && p.disableJSON(err) {
+		return p.generateStream(ctx, messages, onUpdate)
+	}
+	return candidates, err
+}
+
+// generateStream은 Ollama chat API를 스트리밍으로 한 번 호출합니다.
+func (p *OllamaProvider) generateStream(ctx context.Context, messages []Message, onUpdate StreamFunc) ([]string, error) {
+	resp, err := p.send(ctx, systemPrompt(p.format != nil), messages, p.format, true)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	candidates := newCandidateStream(onUpdate, p.format != nil)
+	decoder := json.NewDecoder(resp.Body)
+
+	for {
+		var chunk ollamaResponse
+		if err := decoder.Decode(&chunk); err != nil {
+			if errors.Is(err, io.EOF) {
+				break
+			}
+			return nil, fmt.Errorf("failed to receive stream: %w", err)
+		}
+
+		if chunk.Error != "" {
+			return nil, fmt.Errorf("failed to receive stream: %s", chunk.Error)
+		}
+
+		candidates.Write(chunk.Message.Content)
+
+		if chunk.Done {
+			break
+		}
+	}
+
+	if candidates.Text() == "" {
+		return nil, fmt.Errorf("empty response from Ollama")
+	}
+
+	return candidates.Result(), nil
+}
+
+// send는 /api/chat 요청을 보내고, 성공 응답이 아니면 APIError를 반환합니다.
+func (p *OllamaProvider) send(ctx context.Context, system string, messages []Message, format json.RawMessage, stream bool) (*http.Response, error) {
+	chat := []ollamaMessage{{Role: "system", Content: system}}
+	for _, message := range messages {
+		chat = append(chat, ollamaMessage{Role: string(message.Role), Content: message.Content})
+	}
+
+	body, err := json.Marshal(ollamaRequest{
+		Model:    p.model,
+		Messages: chat,
+		Stream:   stream,
+		Format:   format,
+		Options: ollamaOptions{
+			Temperature: p.temperature,
+			NumPredict:  p.maxTokens,
+		},
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal request: %w", err)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, p.baseURL+"/api/chat", bytes.NewReader(body))
+	if err != nil {
+		return nil, fmt.Errorf("failed to create request: %w", err)
+	}
+	req.Header.Set("Content-Type", "application/json")
+
+	resp, err := p.httpClient.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("failed to reach Ollama at %s: %w", p.baseURL, err)
+	}
+
+	if resp.StatusCode != http.StatusOK {
+		defer resp.Body.Close()
+		data, _ := io.ReadAll(resp.Body)
+
+		// Ollama는 {"error": "..."} 형식으로 에러를 반환
+		apiErr := newAPIError("ollama", resp, data)
+		var result ollamaResponse
+		if err := json.Unmarshal(data, &result); err == nil && result.Error != "" {
+			apiErr.Message = result.Error
+		}
+		return nil, fmt.Errorf("failed to generate completion: %w", apiErr)
+	}
+
+	return resp, nil
+}
+
+// disableJSON은 err가 format 옵션을 거부한 에러이면 JSON 모드를 끄고 true를 반환합니다.
+func (p *OllamaProvider) disableJSON(err error) bool {
+	if p.format == nil || !isUnsupportedFormatError(err, ollamaFormatErrorPattern) {
+		return false
+	}
+	if p.logf != nil {
+		p.logf("ollama rejected the JSON format option, falling back to text mode: %v", err)
+	}
+	p.format = nil
+	return true
+}
+
+// JSONMode는 format 옵션으로 JSON 응답을 요청하는지 반환합니다 (서버가 거부한 뒤에는 false).
+func (p *OllamaProvider) JSONMode() bool {
+	return p.format != nil
+}
+
+// Close는 유휴 연결을 정리합니다.
+func (p *OllamaProvider) Close() error {
+	p.httpClient.CloseIdleConnections()
+	return nil
+}
@@ -0,0 +1,227 @@
+package llm
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+
+	"github.com/sashabaranov/go-openai"
+)
+
+// OpenAI 호환 제공자의 기본값
+const (
+	openAIDefaultBaseURL = "https://api.openai.com/v1"
+	openAIDefaultModel   = "gpt-4o-mini"
+)
+
+// OpenAIProvider는 OpenAI 호환 Chat Completions API를 사용하는 제공자입니다.
+// base URL과 모델을 설정으로 받으므로 사내 게이트웨이 등에도 사용할 수 있습니다.
+type OpenAIProvider struct {
+	name        string
+	client      *openai.Client
+	model       string
+	temperature float32
+	maxTokens   int
+	logf        func(format string, args ...any)
+
+	// JSON 모드에서 요청할 응답 형식 (nil이면 텍스트)
+	responseFormat *openai.ChatCompletionResponseFormat
+}
+
+// NewOpenAIProvider는 새로운 OpenAIProvider 인스턴스를 생성합니다.
+// JSON 모드에서는 JSON Schema(response_format: json_schema)로 응답 형식을 고정합니다.
+func NewOpenAIProvider(opts Options) (*OpenAIProvider, error) {
+	return newOpenAICompatible("openai", opts.withDefaults(openAIDefaultBaseURL, openAIDefaultModel), openai.ChatCompletionResponseFormatTypeJSONSchema)
+}
+
+// newOpenAICompatible은 기본값이 채워진 옵션으로 go-openai 클라이언트를 구성합니다.
+// name은 에러 메시지에 표시할 제공자 이름이고, format은 JSON 모드에서 사용할 응답 형식입니다.
+func newOpenAICompatible(name string, opts Options, format openai.ChatCompletionResponseFormatType) (*OpenAIProvider, error) {
+	if opts.BaseURL == "" {
+		return nil, fmt.Errorf("base URL is required")
+	}
+
+	config := openai.DefaultConfig(opts.APIKey)
+	config.BaseURL = opts.BaseURL
+	config.HTTPClient = &apiErrorDoer{provider: name, client: &http.Client{}}
+
+	provider := &OpenAIProvider{
+		name:        name,
+		client:      openai.NewClientWithConfig(config),
+		model:       opts.Model,
+		temperature: opts.Temperature,
+		maxTokens:   opts.MaxTokens,
+		logf:        opts.Logf,
+	}
+
+	if opts.JSONMode {
+		provider.responseFormat = &openai.ChatCompletionResponseFormat{Type: format}
+		if format == openai.ChatCompletionResponseFormatTypeJSONSchema {
+			provider.responseFormat.JSONSchema = &openai.ChatCompletionResponseFormatJSONSchema{
+				Name:   "commit_messages",
+				Schema: candidatesSchema,
+				Strict: true,
+			}
+		}
+	}
+
+	return provider, nil
+}
+
+// Generate는 Chat Completions API를 호출하여 커밋 메시지 후보들을 생성합니다.
+// 백엔드가 JSON 응답 형식을 거부하면 텍스트 모드로 한 번 다시 요청합니다.
+func (p *OpenAIProvider) Generate(ctx context.Context, messages []Message) ([]string, error) {
+	candidates, err := p.generate(ctx, messages)
+	if err != nil && p.disableJSON(err) {
+		return p.generate(ctx, messages)
+	}
+	return candidates, err
+}
+
+// generate는 Chat Completions API를 한 번 호출합니다.
+func (p *OpenAIProvider) generate(ctx context.Context, messages []Message) ([]string, error) {
+	resp, err := p.client.CreateChatCompletion(ctx, p.newRequest(messages))
+	if err != nil {
+		return nil, fmt.Errorf("failed to generate completion: %w", err)
+	}
+
+	if len(resp.Choices) == 0 {
+		return nil, fmt.Errorf("no choices in response")
+	}
+
+	return parseOutput(resp.Choices[0].Message.Content, p.responseFormat != nil), nil
+}
+
+// GenerateStream은 스트리밍 Chat Completions API로 후보를 생성하며 진행 상황을 전달합니다.
+// 백엔드가 JSON 응답 형식을 거부하면 텍스트 모드로 한 번 다시 요청합니다.
+func (p *OpenAIProvider) GenerateStream(ctx context.Context, messages []Message, onUpdate StreamFunc) ([]string, error) {
+	candidates, err := p.generateStream(ctx, messages, onUpdate)
+	if err != nil && p.disableJSON(err) {
+		return p.generateStream(ctx, messages, onUpdate)
+	}
+	return candidates, err
+}
+
+// generateStream은 스트리밍 Chat Completions API를 한 번 호출합니다.
+func (p *OpenAIProvider) generateStream(ctx context.Context, messages []Message, onUpdate StreamFunc) ([]string, error) {
+	req := p.newRequest(messages)
+	req.Stream = true
+
+	stream, err := p.client.CreateChatCompletionStream(ctx, req)
+	if err != nil {
+		return nil, fmt.Errorf("failed to generate completion: %w", err)
+	}
+	defer stream.Close()
+
+	candidates := newCandidateStream(onUpdate, p.responseFormat != nil)
+	for {
+		resp, err := stream.Recv()
+		if errors.Is(err, io.EOF) {
+			break
+		}
+		if err != nil {
+			return nil, fmt.Errorf("failed to receive stream: %w", err)
+		}
+
+		if len(resp.Choices) > 0 {
+			candidates.Write(resp.Choices[0].Delta.Content)
+		}
+	}
+
+	if candidates.Text() == "" {
+		return nil, fmt.Errorf("no choices in response")
+	}
+
+	return candidates.Result(), nil
+}
+
+// GenerateText는 응답 형식 옵션 없이 Chat Completions API를 호출해 텍스트를 그대로 반환합니다.
+func (p *OpenAIProvider) GenerateText(ctx context.Context, system, prompt string) (string, error) {
+	req := p.newRequest(UserMessages(prompt))
+	req.Messages[0].Content = system
+	req.ResponseFormat = nil
+
+	resp, err := p.client.CreateChatCompletion(ctx, req)
+	if err != nil {
+		return "", fmt.Errorf("failed to generate completion: %w", err)
+	}
+
+	if len(resp.Choices) == 0 {
+		return "", fmt.Errorf("no choices in response")
+	}
+
+	return resp.Choices[0].Message.Content, nil
+}
+
+// disableJSON은 err가 JSON 응답 형식을 거부한 에러이면 JSON 모드를 끄고 true를 반환합니다.
+func (p *OpenAIProvider) disableJSON(err error) bool {
+	if p.responseFormat == nil || !isUnsupportedFormatError(err, responseFormatErrorPattern) {
+		return false
+	}
+	if p.logf != nil {
+		p.logf("%s rejected the JSON response format, falling back to text mode: %v", p.name, err)
+	}
+	p.responseFormat = nil
+	return true
+}
+
+// JSONMode는 JSON 응답 형식을 요청하는지 반환합니다 (백엔드가 거부한 뒤에는 false).
+func (p *OpenAIProvider) JSONMode() bool {
+	return p.responseFormat != nil
+}
+
+// newRequest는 system 지시문과 대화 메시지로 요청을 구성합니다.
+func (p *OpenAIProvider) newRequest(messages []Message) openai.ChatCompletionRequest {
+	chat := []openai.ChatCompletionMessage{
+		{
+			Role:    openai.ChatMessageRoleSystem,
+			Content: systemPrompt(p.responseFormat != nil),
+		},
+	}
+	for _, message := range messages {
+		chat = append(chat, openai.ChatCompletionMessage{
+			Role:    string(message.Role),
+			Content: message.Content,
+		})
+	}
+
+	return openai.ChatCompletionRequest{
+		Model:          p.model,
+		Messages:       chat,
+		Temperature:    p.temperature,
+		MaxTokens:      p.maxTokens,
+		ResponseFormat: p.responseFormat,
+	}
+}
+
+// Close는 클라이언트를 닫습니다.
+func (p *OpenAIProvider) Close() error {
+	// openai.Client에는 Close 메서드가 없음
+	return nil
+}
+
+// apiErrorDoer는 실패 응답을 go-openai보다 먼저 가로채 APIError로 변환합니다.
+// go-openai의 에러 타입은 Retry-After 헤더를 보존하지 않기 때문입니다.
+type apiErrorDoer struct {
+	provider string
+	client   *http.Client
+}
+
+// Do는 요청을 보내고, 상태 코드가 400 이상이면 APIError를 반환합니다.
+func (d *apiErrorDoer) Do(req *http.Request) (*http.Response, error) {
+	resp, err := d.client.Do(req)
+	if err != nil {
+		return nil, err
+	}
+
+	if resp.StatusCode < http.StatusBadRequest {
+		return resp, nil
+	}
+
+	defer resp.Body.Close()
+	data, _ := io.ReadAll(io.LimitReader(resp.Body, 64<<10))
+
+	return nil, newAPIError(d.provider, resp, data)
+}
@@ -1,21 +1,150 @@
 package llm
 
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+)
+
+// Role은 대화 메시지의 역할입니다.
+type Role string
+
+// 대화 메시지 역할 (system 지시문은 각 제공자가 따로 붙임)
+const (
+	RoleUser      Role = "user"
+	RoleAssistant Role = "assistant"
+)
+
+// Message는 LLM에 전달하는 대화 메시지 하나입니다.
+// 재추천할 때는 이전 응답(assistant)과 사용자의 요청(user)을 이어 붙여 대화로 전달합니다.
+type Message struct {
+	Role    Role
+	Content string
+}
+
+// UserMessages는 프롬프트 하나로 이루어진 대화를 반환합니다.
+func UserMessages(prompt string) []Message {
+	return []Message{{Role: RoleUser, Content: prompt}}
+}
+
 // Provider는 LLM 제공자를 위한 인터페이스입니다.
 type Provider interface {
-	// Generate는 주어진 프롬프트로부터 커밋 메시지 후보들을 생성합니다.
-	Generate(prompt string) ([]string, error)
+	// Generate는 대화 메시지로부터 커밋 메시지 후보들을 생성합니다.
+	// 마지막 메시지는 user 메시지여야 합니다.
+	// ctx가 취소되거나 만료되면 진행 중인 요청을 중단합니다.
+	Generate(ctx context.Context, messages []Message) ([]string, error)
 	// Close는 리소스를 정리합니다.
 	Close() error
 }
 
+// TextGenerator는 후보 파싱 없이 일반 텍스트 응답을 생성할 수 있는 Provider입니다.
+// 큰 diff를 파일 그룹별로 요약하는 등 커밋 메시지 외의 보조 호출에 사용합니다.
+type TextGenerator interface {
+	// GenerateText는 system 지시문과 프롬프트로 텍스트 응답 하나를 생성합니다.
+	GenerateText(ctx context.Context, system, prompt string) (string, error)
+}
+
+// JSONModeReporter는 현재 JSON 형식 응답을 요청하고 있는지 알려 주는 Provider입니다.
+// 백엔드가 응답 형식을 거부하면 텍스트 모드로 바뀌므로, 대화를 이어 갈 때마다 다시 확인해야 합니다.
+type JSONModeReporter interface {
+	// JSONMode는 다음 호출에서 JSON 형식 응답을 요청하는지 반환합니다.
+	JSONMode() bool
+}
+
+// JSONMode는 provider가 현재 JSON 형식 응답을 요청하는지 반환합니다.
+// JSONModeReporter를 구현하지 않은 제공자는 번호 형식 텍스트를 사용하는 것으로 봅니다.
+func JSONMode(provider Provider) bool {
+	reporter, ok := provider.(JSONModeReporter)
+	return ok && reporter.JSONMode()
+}
+
+// errTextUnsupported는 제공자가 TextGenerator를 구현하지 않았음을 나타냅니다.
+var errTextUnsupported = errors.New("provider does not support text generation")
+
+// Options는 Provider 생성에 필요한 설정입니다.
+// 비어 있는 값은 각 제공자의 기본값으로 대체됩니다.
+type Options struct {
+	APIKey      string  // API 키
+	BaseURL     string  // API 엔드포인트 (OpenAI 호환 게이트웨이 등)
+	Model       string  // 모델 이름
+	Temperature float32 // 샘플링 온도
+	MaxTokens   int     // 최대 응답 토큰 수
+
+	// JSONMode가 켜져 있으면 지원하는 제공자는 JSON 형식 응답을 요청합니다.
+	// 백엔드가 거부하면 번호 형식 텍스트로 대체합니다.
+	JSONMode bool
+
+	// Logf가 설정되어 있으면 JSON 모드를 끄고 텍스트로 대체할 때 호출됩니다 (verbose 출력용).
+	Logf func(format string, args ...any)
+}
+
+// 모든 제공자가 공통으로 사용하는 기본값
+const (
+	defaultTemperature = 0.5 // 낮춰서 더 일관된 응답 유도 (0.7 → 0.5)
+	defaultMaxTokens   = 4096
+)
+
+// systemMessage는 모든 제공자에 전달되는 system 지시문입니다.
+const systemMessage = `You are a commit message generator that strictly follows instructions.
+
+CRITICAL RULES:
+1. When asked for multi-line format, you MUST provide multi-line commit messages
+2. Multi-line format structure:
+   - Title line (type(scope): summary)
+   - Blank line
+   - Detailed bullet points with proper indentation
+3. Follow the exact format shown in examples
+4. Preserve indentation and blank lines exactly as instructed
+
+Always follow the detail level instructions in the user prompt precisely.`
+
 // NewProvider는 설정에 따른 Provider 인스턴스를 반환합니다.
-// 현재는 Groq만 지원합니다.
-func NewProvider(model string, apiKey string) (Provider, error) {
-	switch model {
+// 알 수 없는 제공자 이름이면 에러를 반환합니다.
+func NewProvider(name string, opts Options) (Provider, error) {
+	switch strings.ToLower(name) {
 	case "groq":
-		return NewGroqProvider(apiKey)
+		return NewGroqProvider(opts)
+	case "openai":
+		return NewOpenAIProvider(opts)
+	case "anthropic", "claude":
+		return NewAnthropicProvider(opts)
+	case "ollama":
+		return NewOllamaProvider(opts)
 	default:
-		// 기본값은 Groq
-		return NewGroqProvider(apiKey)
+		return nil, fmt.Errorf("unknown provider: %s (supported: %s)", name, strings.Join(SupportedProviders(), ", "))
+	}
+}
+
+// SupportedProviders는 지원하는 제공자 이름 목록을 반환합니다.
+func SupportedProviders() []string {
+	return []string{"groq", "openai", "anthropic", "ollama"}
+}
+
+// SupportsJSONMode는 제공자가 JSON 형식 응답을 요청할 수 있는지 확인합니다.
+// Anthropic Messages API에는 응답 형식 옵션이 없으므로 항상 번호 형식 텍스트를 사용합니다.
+func SupportsJSONMode(name string) bool {
+	switch strings.ToLower(name) {
+	case "groq", "openai", "ollama":
+		return true
+	default:
+		return false
+	}
+}
+
+// withDefaults는 비어 있는 옵션 값을 기본값으로 채운 복사본을 반환합니다.
+func (o Options) withDefaults(baseURL, model string) Options {
+	if o.BaseURL == "" {
+		o.BaseURL = baseURL
+	}
+	if o.Model == "" {
+		o.Model = model
+	}
+	if o.Temperature == 0 {
+		o.Temperature = defaultTemperature
+	}
+	if o.MaxTokens == 0 {
+		o.MaxTokens = defaultMaxTokens
 	}
+	return o
 }
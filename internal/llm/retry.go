@@ -0,0 +1,196 @@
+package llm
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"math/rand"
+	"net"
+	"net/http"
+	"time"
+)
+
+// 재시도 기본값
+const (
+	defaultRetryBaseDelay = 1 * time.Second
+	defaultRetryMaxDelay  = 30 * time.Second
+)
+
+// RetryOptions는 RetryProvider의 동작을 설정합니다.
+type RetryOptions struct {
+	MaxRetries int           // 첫 시도 이후 최대 재시도 횟수 (0이면 재시도하지 않음)
+	BaseDelay  time.Duration // 첫 재시도 대기 시간 (0이면 기본값)
+	MaxDelay   time.Duration // 대기 시간 상한 (0이면 기본값)
+
+	// Logf가 설정되어 있으면 재시도할 때마다 호출됩니다 (verbose 출력용).
+	Logf func(format string, args ...any)
+}
+
+// RetryProvider는 다른 Provider를 감싸 일시적인 실패를 재시도합니다.
+// 429와 5xx, 네트워크 에러는 jitter가 적용된 지수 백오프로 재시도하고,
+// Retry-After 헤더가 있으면 그 값을 우선합니다. 인증 에러 등 나머지 4xx는 즉시 반환합니다.
+// Retry-After가 MaxDelay를 넘거나 대기 시간이 ctx의 남은 시간보다 길면 폴백할 수 있도록 바로 반환합니다.
+type RetryProvider struct {
+	provider Provider
+	opts     RetryOptions
+}
+
+// NewRetryProvider는 새로운 RetryProvider 인스턴스를 생성합니다.
+func NewRetryProvider(provider Provider, opts RetryOptions) *RetryProvider {
+	if opts.BaseDelay <= 0 {
+		opts.BaseDelay = defaultRetryBaseDelay
+	}
+	if opts.MaxDelay <= 0 {
+		opts.MaxDelay = defaultRetryMaxDelay
+	}
+	if opts.MaxRetries < 0 {
+		opts.MaxRetries = 0
+	}
+
+	return &RetryProvider{
+		provider: provider,
+		opts:     opts,
+	}
+}
+
+// Generate는 재시도 정책에 따라 내부 Provider의 Generate를 호출합니다.
+func (r *RetryProvider) Generate(ctx context.Context, messages []Message) ([]string, error) {
+	return retryCall(ctx, r, func() ([]string, error) {
+		return r.provider.Generate(ctx, messages)
+	}, nil)
+}
+
+// GenerateStream은 재시도 정책에 따라 내부 Provider를 스트리밍으로 호출합니다.
+// 이미 일부 응답이 전달된 뒤의 실패는 화면 출력이 섞이지 않도록 재시도하지 않습니다.
+func (r *RetryProvider) GenerateStream(ctx context.Context, messages []Message, onUpdate StreamFunc) ([]string, error) {
+	streamed := false
+	return retryCall(ctx, r, func() ([]string, error) {
+		return GenerateStream(ctx, r.provider, messages, func(update StreamUpdate) {
+			streamed = true
+			onUpdate(update)
+		})
+	}, func() bool { return !streamed })
+}
+
+// GenerateText는 재시도 정책에 따라 내부 Provider의 GenerateText를 호출합니다.
+func (r *RetryProvider) GenerateText(ctx context.Context, system, prompt string) (string, error) {
+	generator, ok := r.provider.(TextGenerator)
+	if !ok {
+		return "", errTextUnsupported
+	}
+
+	return retryCall(ctx, r, func() (string, error) {
+		return generator.GenerateText(ctx, system, prompt)
+	}, nil)
+}
+
+// JSONMode는 내부 Provider가 JSON 형식 응답을 요청하는지 반환합니다.
+func (r *RetryProvider) JSONMode() bool {
+	return JSONMode(r.provider)
+}
+
+// retryCall은 call을 r의 재시도 정책에 따라 반복 호출합니다.
+// canRetry가 nil이 아니면 재시도 전에 추가로 확인합니다.
+func retryCall[T any](ctx context.Context, r *RetryProvider, call func() (T, error), canRetry func() bool) (T, error) {
+	var zero T
+	attempts := r.opts.MaxRetries + 1
+
+	for attempt := 1; ; attempt++ {
+		result, err := call()
+		if err == nil {
+			return result, nil
+		}
+
+		retryable, retryAfter := classifyError(err)
+		if !retryable || ctx.Err() != nil || (canRetry != nil && !canRetry()) {
+			return zero, err
+		}
+		if attempt >= attempts {
+			if attempts > 1 {
+				return zero, fmt.Errorf("giving up after %d attempts: %w", attempts, err)
+			}
+			return zero, err
+		}
+
+		delay := retryAfter
+		if delay <= 0 {
+			delay = r.backoff(attempt)
+		}
+
+		// 서버가 요청한 대기 시간이 상한을 넘거나 대기가 남은 시간 안에 끝나지 않으면 기다리지 않고 반환
+		// (남은 시간을 다 쓰면 FallbackProvider가 다음 제공자를 시도할 수 없음)
+		if retryAfter > r.opts.MaxDelay || exceedsDeadline(ctx, delay) {
+			if r.opts.Logf != nil {
+				r.opts.Logf("not retrying: waiting %s exceeds the retry limit or the timeout: %v", delay.Round(100*time.Millisecond), err)
+			}
+			return zero, err
+		}
+
+		if r.opts.Logf != nil {
+			r.opts.Logf("retrying in %s (attempt %d/%d): %v", delay.Round(100*time.Millisecond), attempt+1, attempts, err)
+		}
+
+		timer := time.NewTimer(delay)
+		select {
+		case <-ctx.Done():
+			timer.Stop()
+			return zero, ctx.Err()
+		case <-timer.C:
+		}
+	}
+}
+
+// exceedsDeadline은 delay만큼 기다리면 ctx의 마감 시간을 넘는지 확인합니다.
+func exceedsDeadline(ctx context.Context, delay time.Duration) bool {
+	deadline, ok := ctx.Deadline()
+	return ok && delay >= time.Until(deadline)
+}
+
+// Close는 내부 Provider를 닫습니다.
+func (r *RetryProvider) Close() error {
+	return r.provider.Close()
+}
+
+// backoff는 attempt번째 실패 후의 대기 시간을 계산합니다.
+// base * 2^(attempt-1)을 상한으로 자른 뒤, 그 절반 이상 범위에서 무작위로 선택합니다.
+func (r *RetryProvider) backoff(attempt int) time.Duration {
+	delay := r.opts.BaseDelay << (attempt - 1)
+	if delay <= 0 || delay > r.opts.MaxDelay {
+		delay = r.opts.MaxDelay
+	}
+
+	half := delay / 2
+	return half + time.Duration(rand.Int63n(int64(half)+1))
+}
+
+// classifyError는 에러가 재시도 가능한지와 서버가 요청한 대기 시간을 반환합니다.
+func classifyError(err error) (retryable bool, retryAfter time.Duration) {
+	if errors.Is(err, context.Canceled) || errors.Is(err, context.DeadlineExceeded) {
+		return false, 0
+	}
+
+	var apiErr *APIError
+	if errors.As(err, &apiErr) {
+		switch {
+		case apiErr.StatusCode == http.StatusTooManyRequests,
+			apiErr.StatusCode == http.StatusRequestTimeout,
+			apiErr.StatusCode >= http.StatusInternalServerError:
+			return true, apiErr.RetryAfter
+		default:
+			// 401/403 등 인증 에러와 나머지 4xx는 재시도해도 결과가 같음
+			return false, 0
+		}
+	}
+
+	var netErr net.Error
+	if errors.As(err, &netErr) {
+		return true, 0
+	}
+
+	if errors.Is(err, io.ErrUnexpectedEOF) {
+		return true, 0
+	}
+
+	return false, 0
+}
@@ -0,0 +1,88 @@
+package llm
+
+import "context"
+
+// StreamUpdate는 스트리밍 중 전달되는 생성 진행 상황입니다.
+type StreamUpdate struct {
+	Candidates []string // 완성된 후보들
+	Partial    string   // 작성 중인 후보 (없으면 빈 문자열)
+}
+
+// StreamFunc는 스트리밍 진행 상황을 받는 콜백입니다.
+type StreamFunc func(update StreamUpdate)
+
+// StreamingProvider는 응답을 생성되는 대로 전달할 수 있는 Provider입니다.
+type StreamingProvider interface {
+	Provider
+	// GenerateStream은 Generate와 같은 결과를 반환하되, 생성 중에 onUpdate를 반복 호출합니다.
+	GenerateStream(ctx context.Context, messages []Message, onUpdate StreamFunc) ([]string, error)
+}
+
+// GenerateStream은 provider가 스트리밍을 지원하면 스트리밍으로, 아니면 일반 호출로 생성합니다.
+// 일반 호출인 경우 완료 시점에 onUpdate를 한 번 호출합니다.
+func GenerateStream(ctx context.Context, provider Provider, messages []Message, onUpdate StreamFunc) ([]string, error) {
+	if streaming, ok := provider.(StreamingProvider); ok {
+		return streaming.GenerateStream(ctx, messages, onUpdate)
+	}
+
+	candidates, err := provider.Generate(ctx, messages)
+	if err != nil {
+		return nil, err
+	}
+
+	onUpdate(StreamUpdate{Candidates: candidates})
+	return candidates, nil
+}
+
+// candidateStream은 스트리밍 조각을 모아 후보를 점진적으로 파싱하고 onUpdate에 전달합니다.
+// JSON 모드에서는 JSON 응답이 시작되면 JSON 스캐너를, 아니면 번호 형식 파서를 사용합니다.
+type candidateStream struct {
+	parser   candidateParser
+	scanner  *candidateScanner
+	text     []byte
+	onUpdate StreamFunc
+}
+
+// newCandidateStream은 새로운 candidateStream을 생성합니다.
+func newCandidateStream(onUpdate StreamFunc, jsonMode bool) *candidateStream {
+	s := &candidateStream{onUpdate: onUpdate}
+	if jsonMode {
+		s.scanner = newCandidateScanner()
+	}
+	return s
+}
+
+// Write는 응답 조각을 추가하고 진행 상황을 전달합니다.
+func (s *candidateStream) Write(chunk string) {
+	if chunk == "" {
+		return
+	}
+
+	s.text = append(s.text, chunk...)
+	s.parser.Write(chunk)
+
+	candidates, partial := s.parser.Snapshot()
+	if s.scanner != nil {
+		s.scanner.Write(chunk)
+		if s.scanner.Started() {
+			candidates, partial = s.scanner.Snapshot()
+		}
+	}
+
+	s.onUpdate(StreamUpdate{
+		Candidates: candidates,
+		Partial:    partial,
+	})
+}
+
+// Result는 전체 응답을 파싱한 최종 후보 목록을 반환합니다.
+func (s *candidateStream) Result() []string {
+	messages := parseOutput(string(s.text), s.scanner != nil)
+	s.onUpdate(StreamUpdate{Candidates: messages})
+	return messages
+}
+
+// Text는 지금까지 받은 전체 응답을 반환합니다.
+func (s *candidateStream) Text() string {
+	return string(s.text)
+}
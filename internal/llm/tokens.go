@@ -0,0 +1,98 @@
+package llm
+
+import (
+	"strings"
+	"unicode/utf8"
+)
+
+// 토큰 예산 기본값
+const (
+	defaultContextWindow = 8192  // 알 수 없는 모델의 컨텍스트 크기
+	maxPromptBudget      = 16000 // 큰 모델이라도 프롬프트에 쓰는 최대 토큰 수 (쿼터 보호)
+	minPromptBudget      = 2000  // 작은 모델에서도 보장하는 최소 토큰 수
+	systemReserve        = 512   // system 지시문 등 프롬프트 외 입력에 남겨두는 토큰 수
+)
+
+// contextWindows는 제공자별 모델 이름 접두사와 컨텍스트 크기입니다.
+// 먼저 나온 접두사가 우선하며, 빈 접두사는 제공자 기본값입니다.
+var contextWindows = map[string][]struct {
+	prefix string
+	tokens int
+}{
+	"groq": {
+		{"llama3-", 8192},
+		{"gemma", 8192},
+		{"", 131072},
+	},
+	"openai": {
+		{"gpt-4.1", 1047576},
+		{"gpt-4o", 128000},
+		{"gpt-4-turbo", 128000},
+		{"gpt-4", 8192},
+		{"gpt-3.5", 16385},
+		{"o1", 200000},
+		{"o3", 200000},
+		{"o4", 200000},
+		{"", 128000},
+	},
+	"anthropic": {
+		{"", 200000},
+	},
+	"ollama": {
+		// Ollama는 모델과 관계없이 num_ctx 기본값으로 잘라냄
+		{"", 8192},
+	},
+}
+
+// EstimateTokens는 텍스트의 토큰 수를 대략적으로 추정합니다.
+// ASCII는 4글자당 1토큰, 한글 등 멀티바이트 문자는 글자당 1토큰으로 계산합니다.
+func EstimateTokens(text string) int {
+	ascii := 0
+	other := 0
+	for i := 0; i < len(text); {
+		if text[i] < utf8.RuneSelf {
+			ascii++
+			i++
+			continue
+		}
+		_, size := utf8.DecodeRuneInString(text[i:])
+		other++
+		i += size
+	}
+	return (ascii+3)/4 + other
+}
+
+// ContextWindow는 제공자와 모델의 컨텍스트 크기(토큰)를 반환합니다.
+// model이 비어 있으면 제공자 기본 모델 기준이며, 알 수 없으면 보수적인 기본값을 사용합니다.
+func ContextWindow(provider, model string) int {
+	provider = strings.ToLower(provider)
+	if provider == "claude" {
+		provider = "anthropic"
+	}
+
+	model = strings.ToLower(model)
+	for _, entry := range contextWindows[provider] {
+		if strings.HasPrefix(model, entry.prefix) {
+			return entry.tokens
+		}
+	}
+	return defaultContextWindow
+}
+
+// PromptBudget은 제공자와 모델에서 프롬프트에 사용할 토큰 예산을 반환합니다.
+// 컨텍스트 크기에서 응답 토큰(maxTokens, 0이면 기본값)과 system 지시문 몫을 빼고,
+// 쿼터 보호를 위해 상한을 적용합니다.
+func PromptBudget(provider, model string, maxTokens int) int {
+	if maxTokens <= 0 {
+		maxTokens = defaultMaxTokens
+	}
+
+	budget := ContextWindow(provider, model) - maxTokens - systemReserve
+	if budget > maxPromptBudget {
+		budget = maxPromptBudget
+	}
+	if budget < minPromptBudget {
+		budget = minPromptBudget
+	}
+	return budget
+}
@@ -1,85 +1,131 @@
 package llm
 
+import "strings"
+
+// parseResponse는 응답 텍스트를 후보 목록으로 변환합니다.
+// 번호 형식을 찾지 못하면 응답 전체를 하나의 후보로 사용합니다.
+func parseResponse(text string) []string {
+	messages := parseCommitMessages(text)
+
+	if len(messages) == 0 {
+		return []string{text}
+	}
+
+	return messages
+}
+
 // parseCommitMessages는 응답 텍스트에서 커밋 메시지 후보들을 추출합니다.
 func parseCommitMessages(text string) []string {
-	var messages []string
-	lines := splitLines(text)
-
-	i := 0
-	for i < len(lines) {
-		line := trimWhitespace(lines[i])
-		if line == "" {
-			i++
-			continue
+	parser := &candidateParser{}
+	parser.Write(text)
+	parser.Flush()
+	return parser.messages
+}
+
+// candidateParser는 응답 텍스트를 조각 단위로 받아 후보를 점진적으로 추출합니다.
+// 스트리밍 중에는 다음 번호가 시작되는 순간 이전 후보가 완성됩니다.
+type candidateParser struct {
+	pending    string   // 아직 줄바꿈을 만나지 않은 마지막 줄
+	messages   []string // 완성된 후보들
+	current    string   // 작성 중인 후보
+	inMessage  bool     // 번호 형식 이후 줄을 수집 중인지 여부
+	emptyLines int      // 연속된 빈 줄 수
+}
+
+// Write는 텍스트 조각을 추가하고 완성된 줄을 처리합니다.
+func (p *candidateParser) Write(chunk string) {
+	p.pending += chunk
+
+	for {
+		idx := strings.IndexByte(p.pending, '\n')
+		if idx < 0 {
+			return
 		}
 
-		// "1) ", "2) ", "1. ", "2. " 등의 패턴 감지
-		if isNumberedFormat(line) {
-			msg := removeNumberPrefix(line)
-
-			// 다음 줄들을 계속 수집 (다음 번호가 나올 때까지)
-			i++
-			consecutiveEmptyLines := 0
-
-			for i < len(lines) {
-				nextLine := lines[i] // trimWhitespace 하지 않고 원본 유지
-				trimmedNext := trimWhitespace(nextLine)
-
-				// 다음 번호 형식이 나오면 중지
-				if isNumberedFormat(trimmedNext) {
-					break
-				}
-
-				// 연속된 빈 줄이 2개 이상이면 메시지의 끝으로 간주
-				if trimmedNext == "" {
-					consecutiveEmptyLines++
-					if consecutiveEmptyLines >= 2 {
-						break
-					}
-				} else {
-					consecutiveEmptyLines = 0
-				}
-
-				// 줄 추가 (빈 줄 포함, 들여쓰기 보존)
-				if msg != "" {
-					msg += "\n" + nextLine
-				} else {
-					msg = nextLine
-				}
-
-				i++
-			}
-
-			if msg != "" {
-				messages = append(messages, msg)
-			}
-		} else {
-			i++
+		line := p.pending[:idx]
+		p.pending = p.pending[idx+1:]
+		p.processLine(line)
+	}
+}
+
+// Flush는 남은 줄을 처리하고 작성 중인 후보를 완성합니다.
+func (p *candidateParser) Flush() {
+	if p.pending != "" {
+		p.processLine(p.pending)
+		p.pending = ""
+	}
+	p.finish()
+}
+
+// Snapshot은 지금까지 완성된 후보들과 작성 중인 후보 텍스트를 반환합니다 (줄바꿈 전 조각 포함).
+// 마지막 조각이 다음 번호로 시작하면 작성 중이던 후보는 완성된 것으로 봅니다.
+func (p *candidateParser) Snapshot() (messages []string, partial string) {
+	messages = p.messages
+	pending := trimWhitespace(p.pending)
+
+	if isNumberedFormat(pending) {
+		if p.inMessage && p.current != "" {
+			messages = append(messages[:len(messages):len(messages)], p.current)
 		}
+		return messages, removeNumberPrefix(pending)
 	}
 
-	return messages
+	if !p.inMessage {
+		return messages, ""
+	}
+
+	if p.current == "" {
+		return messages, p.pending
+	}
+	if p.pending == "" {
+		return messages, p.current
+	}
+	return messages, p.current + "\n" + p.pending
 }
 
-// splitLines은 문자열을 줄 단위로 분리합니다.
-func splitLines(s string) []string {
-	lines := []string{}
-	currentLine := ""
+// processLine은 한 줄을 처리합니다.
+func (p *candidateParser) processLine(line string) {
+	trimmed := trimWhitespace(line)
 
-	for _, r := range s {
-		if r == '\n' {
-			lines = append(lines, currentLine)
-			currentLine = ""
-		} else {
-			currentLine += string(r)
+	// "1) ", "2) ", "1. ", "2. " 등의 패턴 감지 → 새 후보 시작
+	if isNumberedFormat(trimmed) {
+		p.finish()
+		p.current = removeNumberPrefix(trimmed)
+		p.inMessage = true
+		return
+	}
+
+	if !p.inMessage {
+		return
+	}
+
+	// 연속된 빈 줄이 2개 이상이면 메시지의 끝으로 간주
+	if trimmed == "" {
+		p.emptyLines++
+		if p.emptyLines >= 2 {
+			p.finish()
+			return
 		}
+	} else {
+		p.emptyLines = 0
 	}
 
-	if currentLine != "" {
-		lines = append(lines, currentLine)
+	// 줄 추가 (빈 줄 포함, 들여쓰기 보존)
+	if p.current != "" {
+		p.current += "\n" + line
+	} else {
+		p.current = line
 	}
+}
 
-	return lines
+// finish는 작성 중인 후보를 완성된 목록으로 옮깁니다.
+func (p *candidateParser) finish() {
+	if p.inMessage && p.current != "" {
+		p.messages = append(p.messages, p.current)
+	}
+	p.current = ""
+	p.inMessage = false
+	p.emptyLines = 0
 }
 
 // trimWhitespace는 문자열의 앞뒤 공백을 제거합니다.
@@ -112,34 +158,37 @@ func trimRight(s string) string {
 }
 
 // isNumberedFormat은 문자열이 번호 포맷인지 확인합니다.
+// "1) ", "2. ", "10) "처럼 두 자리까지의 번호를 인식합니다.
 func isNumberedFormat(s string) bool {
-	if len(s) < 3 {
-		return false
-	}
-
-	// 첫 글자가 숫자인지 확인
-	if s[0] < '0' || s[0] > '9' {
-		return false
-	}
-
-	// 두 번째 글자가 ) 또는 .인지 확인
-	secondChar := s[1]
-	return secondChar == ')' || secondChar == '.'
+	return numberPrefixLen(s) > 0
 }
 
 // removeNumberPrefix는 번호 접두사를 제거합니다.
 func removeNumberPrefix(s string) string {
-	if len(s) < 3 {
-		return ""
+	n := numberPrefixLen(s)
+	if n == 0 {
+		if len(s) < 3 {
+			return ""
+		}
+		return s
 	}
+	return trimWhitespace(s[n:])
+}
 
-	// 첫 글자가 숫자이고 두 번째가 ) 또는 .이면 제거
-	if s[0] >= '0' && s[0] <= '9' {
-		secondChar := s[1]
-		if secondChar == ')' || secondChar == '.' {
-			return trimWhitespace(s[2:])
-		}
+// numberPrefixLen은 번호 접두사("1)", "12.")의 길이를 반환합니다.
+// 접두사 뒤에 내용이 없거나 번호 형식이 아니면 0을 반환합니다.
+func numberPrefixLen(s string) int {
+	digits := 0
+	for digits < len(s) && digits < 3 && s[digits] >= '0' && s[digits] <= '9' {
+		digits++
+	}
+	if digits == 0 || digits > 2 || digits+1 >= len(s) {
+		return 0
 	}
 
-	return s
+	// 숫자 다음 글자가 ) 또는 .인지 확인
+	if s[digits] != ')' && s[digits] != '.' {
+		return 0
+	}
+	return digits + 1
 }
@@ -1,6 +1,9 @@
 package model
 
-import "git-ai-commit/internal/git"
+import (
+	"git-ai-commit/internal/git"
+	"git-ai-commit/internal/lint"
+)
 
 // Config는 애플리케이션 설정을 나타냅니다.
 type Config struct {
@@ -17,8 +20,36 @@ type CommitMessage struct {
 
 // GeneratorInput은 메시지 생성기의 입력입니다.
 type GeneratorInput struct {
-	DiffResult *git.DiffResult // 파싱된 diff 결과
-	Detail     string          // 디테일 레벨
+	DiffResult  *git.DiffResult // 파싱된 diff 결과
+	Detail      string          // 디테일 레벨
+	Lang        string          // 언어 (en, ko)
+	TokenBudget int             // 프롬프트 토큰 예산 (0이면 제한 없음)
+	JSONMode    bool            // 제공자에 JSON 형식 응답을 요청하는지 여부 (아니면 번호 형식 지시를 프롬프트에 포함, 제공자가 거부하면 Generator가 끔)
+	Summaries   []ChangeSummary // 파일 그룹별 요약 (비어 있으면 변경 내용 일부를 그대로 사용)
+
+	AllowedTypes  []string   // 허용하는 커밋 타입 (비어 있으면 제한 없음)
+	AllowedScopes []string   // 허용하는 scope (비어 있으면 제한 없음)
+	LintRules     lint.Rules // 후보 형식 보정과 다시 요청 여부를 판단하는 검사 규칙
+
+	Tickets    []string // 브랜치 이름에서 찾은 티켓 번호
+	TicketMode string   // 티켓 번호를 넣는 방식 (footer, prefix, off)
+
+	Feedback []Feedback    // 재추천 요청 기록 (오래된 것부터)
+	Style    *StyleProfile // 저장소의 커밋 스타일 (nil이면 일반 Conventional Commit)
+}
+
+// Feedback은 재추천 요청 한 번의 기록입니다.
+// 이전 후보와 사용자의 요청을 대화로 전달해 다른 후보가 생성되도록 합니다.
+type Feedback struct {
+	Rejected []string // 선택하지 않은 이전 후보
+	Hint     string   // 사용자가 입력한 요청 (비어 있으면 없음)
+}
+
+// ChangeSummary는 큰 diff를 파일 그룹별로 LLM이 요약한 결과입니다.
+type ChangeSummary struct {
+	Group   string   // 그룹 이름 (디렉토리 경로)
+	Paths   []string // 그룹에 포함된 파일 경로
+	Summary string   // 요약 내용
 }
 
 // CommitRequest는 git commit 요청입니다.
@@ -27,3 +58,20 @@ type CommitRequest struct {
 	DryRun     bool   // dry-run 모드 여부
 	AllowEmpty bool   // 빈 커밋 허용 여부
 }
+
+// StyleProfile은 저장소의 최근 커밋 메시지에서 추론한 작성 스타일입니다.
+type StyleProfile struct {
+	Commits          int      `json:"commits"`            // 분석한 커밋 수
+	Conventional     bool     `json:"conventional"`       // 대부분 type(scope): 형식을 사용
+	Types            []string `json:"types"`              // 자주 쓰는 타입 (빈도순)
+	Scopes           []string `json:"scopes"`             // 자주 쓰는 scope (빈도순)
+	AvgSubjectLength int      `json:"avg_subject_length"` // 제목 줄 평균 길이 (글자 수)
+	MaxSubjectLength int      `json:"max_subject_length"` // 제목 줄 길이의 90번째 백분위수
+	Capitalized      bool     `json:"capitalized"`        // 설명을 대문자로 시작
+	TrailingPeriod   bool     `json:"trailing_period"`    // 제목 끝에 마침표 사용
+	TicketPrefix     string   `json:"ticket_prefix"`      // 제목 앞 티켓 번호 예시 (예: "ABC-123", 없으면 빈 문자열)
+	Gitmoji          bool     `json:"gitmoji"`            // 제목 앞에 gitmoji 사용
+	Language         string   `json:"language"`           // 주 언어 (en, ko)
+	BodyStyle        string   `json:"body_style"`         // 본문 형식 (none, bullets, paragraph)
+	Examples         []string `json:"examples"`           // 대표 예시 메시지
+}
@@ -0,0 +1,272 @@
+package style
+
+import (
+	"git-ai-commit/internal/model"
+	"regexp"
+	"sort"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+)
+
+// 분석 기준
+const (
+	MinCommits   = 5   // 스타일을 추론하는 데 필요한 최소 커밋 수
+	maxVocab     = 8   // 프롬프트에 넣을 최대 타입/scope 수
+	maxExamples  = 5   // 프롬프트에 넣을 최대 예시 수
+	exampleLines = 8   // 예시 하나에 넣을 최대 줄 수 (본문이 긴 커밋은 잘라냄)
+	majority     = 0.5 // 과반으로 보는 비율
+	bodyMinimum  = 0.3 // 본문이 있는 커밋이 이 비율 미만이면 본문 없음으로 판단
+)
+
+var (
+	// type(scope)!: subject 형식 (앞의 gitmoji와 티켓 번호는 먼저 제거)
+	conventionalPattern = regexp.MustCompile(`^([a-zA-Z]+)(?:\(([^)]+)\))?!?: (.+)$`)
+	// 제목 앞의 티켓 번호 (ABC-123: ..., [ABC-123] ..., ABC-123 ...)
+	ticketPattern = regexp.MustCompile(`^\[?([A-Z][A-Z0-9]+-\d+)\]?:?\s+`)
+	// :sparkles: 형식의 gitmoji 코드
+	gitmojiCodePattern = regexp.MustCompile(`^:[a-z0-9_+-]+:\s*`)
+)
+
+// subject는 제목 줄 하나를 분석한 결과입니다.
+type subject struct {
+	text        string // 제목 줄 전체
+	ticket      string // 티켓 번호 (없으면 빈 문자열)
+	gitmoji     bool
+	commitType  string // Conventional Commit 타입 (형식이 아니면 빈 문자열)
+	scope       string
+	description string // 타입, 티켓, gitmoji를 뺀 설명
+}
+
+// Analyze는 최신순 커밋 메시지들에서 작성 스타일을 추론합니다.
+// 커밋이 MinCommits개보다 적으면 추론하지 않고 nil을 반환합니다.
+func Analyze(messages []string) *model.StyleProfile {
+	if len(messages) < MinCommits {
+		return nil
+	}
+
+	profile := &model.StyleProfile{Commits: len(messages)}
+
+	subjects := make([]subject, len(messages))
+	lengths := make([]int, len(messages))
+	types := make(map[string]int)
+	scopes := make(map[string]int)
+	var conventional, capitalized, period, gitmoji, korean, withBody, bulletBodies int
+	totalLength := 0
+
+	for i, message := range messages {
+		title, body, _ := strings.Cut(message, "\n")
+		s := parseSubject(strings.TrimSpace(title))
+		subjects[i] = s
+
+		length := utf8.RuneCountInString(s.text)
+		lengths[i] = length
+		totalLength += length
+
+		if s.commitType != "" {
+			conventional++
+			types[s.commitType]++
+			if s.scope != "" {
+				scopes[s.scope]++
+			}
+		}
+		if r, _ := utf8.DecodeRuneInString(s.description); unicode.IsUpper(r) {
+			capitalized++
+		}
+		if strings.HasSuffix(s.text, ".") {
+			period++
+		}
+		if s.gitmoji {
+			gitmoji++
+		}
+		if s.ticket != "" && profile.TicketPrefix == "" {
+			profile.TicketPrefix = s.ticket
+		}
+		if containsHangul(message) {
+			korean++
+		}
+
+		if body = strings.TrimSpace(body); body != "" {
+			withBody++
+			if isBulletBody(body) {
+				bulletBodies++
+			}
+		}
+	}
+
+	total := float64(len(messages))
+	profile.Conventional = float64(conventional)/total > majority
+	profile.Types = topKeys(types, maxVocab)
+	profile.Scopes = topKeys(scopes, maxVocab)
+	profile.AvgSubjectLength = totalLength / len(messages)
+	profile.MaxSubjectLength = percentile(lengths, 0.9)
+	profile.Capitalized = float64(capitalized)/total > majority
+	profile.TrailingPeriod = float64(period)/total > majority
+	profile.Gitmoji = float64(gitmoji)/total > majority
+	if float64(countTickets(subjects))/total <= majority {
+		profile.TicketPrefix = ""
+	}
+
+	profile.Language = "en"
+	if float64(korean)/total > majority {
+		profile.Language = "ko"
+	}
+
+	switch {
+	case float64(withBody)/total < bodyMinimum:
+		profile.BodyStyle = "none"
+	case float64(bulletBodies)/float64(withBody) > majority:
+		profile.BodyStyle = "bullets"
+	default:
+		profile.BodyStyle = "paragraph"
+	}
+
+	profile.Examples = pickExamples(messages, subjects, profile)
+	return profile
+}
+
+// parseSubject는 제목 줄에서 gitmoji, 티켓 번호, Conventional Commit 타입과 scope를 분리합니다.
+func parseSubject(text string) subject {
+	s := subject{text: text}
+	rest := text
+
+	if code := gitmojiCodePattern.FindString(rest); code != "" {
+		s.gitmoji = true
+		rest = rest[len(code):]
+	} else if r, size := utf8.DecodeRuneInString(rest); isEmoji(r) {
+		s.gitmoji = true
+		rest = strings.TrimLeft(rest[size:], " \uFE0F") // 이모지 변형 선택자 포함
+	}
+
+	if match := ticketPattern.FindStringSubmatch(rest); match != nil {
+		s.ticket = match[1]
+		rest = rest[len(match[0]):]
+	}
+
+	if match := conventionalPattern.FindStringSubmatch(rest); match != nil {
+		s.commitType = strings.ToLower(match[1])
+		s.scope = match[2]
+		rest = match[3]
+	}
+
+	s.description = strings.TrimSpace(rest)
+	return s
+}
+
+// pickExamples는 스타일을 잘 보여주는 최근 메시지를 고릅니다.
+// 제목이 너무 긴 메시지는 제외하고, 가능하면 서로 다른 타입을 먼저 고릅니다.
+func pickExamples(messages []string, subjects []subject, profile *model.StyleProfile) []string {
+	var examples []string
+	picked := make(map[int]bool)
+	seenTypes := make(map[string]bool)
+
+	fits := func(i int) bool {
+		if utf8.RuneCountInString(subjects[i].text) > profile.MaxSubjectLength {
+			return false
+		}
+		// 본문 스타일이 없으면 제목만 있는 메시지, 있으면 본문이 있는 메시지를 우선
+		_, body, _ := strings.Cut(messages[i], "\n")
+		return (profile.BodyStyle == "none") == (strings.TrimSpace(body) == "")
+	}
+
+	// 1차: 타입별로 하나씩
+	for i := range messages {
+		if len(examples) == maxExamples {
+			break
+		}
+		if !fits(i) || seenTypes[subjects[i].commitType] {
+			continue
+		}
+		seenTypes[subjects[i].commitType] = true
+		picked[i] = true
+		examples = append(examples, truncateLines(messages[i], exampleLines))
+	}
+
+	// 2차: 남은 자리는 최근 메시지로 채움
+	for i := range messages {
+		if len(examples) == maxExamples {
+			break
+		}
+		if !picked[i] && fits(i) {
+			picked[i] = true
+			examples = append(examples, truncateLines(messages[i], exampleLines))
+		}
+	}
+	return examples
+}
+
+// truncateLines는 메시지를 앞에서부터 최대 limit줄로 자릅니다.
+func truncateLines(message string, limit int) string {
+	lines := strings.Split(strings.TrimRight(message, " \t\n"), "\n")
+	if len(lines) > limit {
+		lines = append(lines[:limit], "...")
+	}
+	return strings.Join(lines, "\n")
+}
+
+// countTickets는 티켓 번호로 시작하는 제목 수를 반환합니다.
+func countTickets(subjects []subject) int {
+	count := 0
+	for _, s := range subjects {
+		if s.ticket != "" {
+			count++
+		}
+	}
+	return count
+}
+
+// isBulletBody는 본문의 대부분 줄이 "-" 또는 "*" 목록인지 확인합니다.
+func isBulletBody(body string) bool {
+	lines, bullets := 0, 0
+	for _, line := range strings.Split(body, "\n") {
+		line = strings.TrimSpace(line)
+		if line == "" {
+			continue
+		}
+		lines++
+		if strings.HasPrefix(line, "- ") || strings.HasPrefix(line, "* ") {
+			bullets++
+		}
+	}
+	return lines > 0 && float64(bullets)/float64(lines) > majority
+}
+
+// topKeys는 빈도가 높은 순서로 최대 limit개의 키를 반환합니다 (빈도가 같으면 이름순).
+func topKeys(counts map[string]int, limit int) []string {
+	keys := make([]string, 0, len(counts))
+	for key := range counts {
+		keys = append(keys, key)
+	}
+	sort.Slice(keys, func(i, j int) bool {
+		if counts[keys[i]] != counts[keys[j]] {
+			return counts[keys[i]] > counts[keys[j]]
+		}
+		return keys[i] < keys[j]
+	})
+	if len(keys) > limit {
+		keys = keys[:limit]
+	}
+	return keys
+}
+
+// percentile은 값들의 p 백분위수를 반환합니다.
+func percentile(values []int, p float64) int {
+	sorted := append([]int(nil), values...)
+	sort.Ints(sorted)
+	return sorted[int(float64(len(sorted)-1)*p)]
+}
+
+// containsHangul은 문자열에 한글이 있는지 확인합니다.
+func containsHangul(s string) bool {
+	for _, r := range s {
+		if unicode.Is(unicode.Hangul, r) {
+			return true
+		}
+	}
+	return false
+}
+
+// isEmoji는 제목 앞의 gitmoji로 쓰이는 그림 문자인지 확인합니다.
+func isEmoji(r rune) bool {
+	return (r >= 0x1F300 && r <= 0x1FAFF) || (r >= 0x2600 && r <= 0x27BF) || r == 0x2B50 || r == 0x231A
+}
@@ -0,0 +1,97 @@
+package ticket
+
+import (
+	"fmt"
+	"regexp"
+	"slices"
+	"strings"
+)
+
+// 티켓 번호를 메시지에 넣는 방식
+const (
+	ModeFooter = "footer" // 본문 아래 "Refs: PROJ-1234" footer
+	ModePrefix = "prefix" // 제목 앞 "[PROJ-1234] " 접두사
+	ModeOff    = "off"    // 브랜치에서 티켓 번호를 읽지 않음
+)
+
+// Modes는 지원하는 모든 방식입니다.
+var Modes = []string{ModeFooter, ModePrefix, ModeOff}
+
+// DefaultPattern은 기본 티켓 번호 형식입니다 (Jira 스타일, 예: PROJ-1234).
+const DefaultPattern = `[A-Z][A-Z0-9]+-[0-9]+`
+
+// FooterToken은 티켓 번호 footer의 키입니다.
+const FooterToken = "Refs"
+
+// trailerPattern은 "Token: value" 형식의 git trailer 줄입니다.
+var trailerPattern = regexp.MustCompile(`^(?:[A-Za-z][A-Za-z0-9-]*|BREAKING CHANGE): \S`)
+
+// Compile은 티켓 번호 정규식들을 컴파일합니다.
+func Compile(patterns []string) ([]*regexp.Regexp, error) {
+	compiled := make([]*regexp.Regexp, 0, len(patterns))
+	for _, pattern := range patterns {
+		re, err := regexp.Compile(pattern)
+		if err != nil {
+			return nil, fmt.Errorf("invalid ticket pattern %q: %w", pattern, err)
+		}
+		compiled = append(compiled, re)
+	}
+	return compiled, nil
+}
+
+// Extract는 브랜치 이름에서 티켓 번호를 찾아 처음 나온 순서대로 중복 없이 반환합니다.
+// 정규식에 캡처 그룹이 있으면 첫 번째 그룹을, 없으면 일치한 전체 문자열을 사용합니다.
+func Extract(branch string, patterns []*regexp.Regexp) []string {
+	var tickets []string
+	for _, re := range patterns {
+		for _, match := range re.FindAllStringSubmatch(branch, -1) {
+			id := match[0]
+			if len(match) > 1 && match[1] != "" {
+				id = match[1]
+			}
+			if !slices.Contains(tickets, id) {
+				tickets = append(tickets, id)
+			}
+		}
+	}
+	return tickets
+}
+
+// Apply는 메시지에 없는 티켓 번호를 mode에 따라 footer나 제목 접두사로 추가합니다.
+// 메시지에 이미 있는 티켓 번호는 다시 넣지 않습니다.
+func Apply(message string, tickets []string, mode string) string {
+	var missing []string
+	for _, id := range tickets {
+		if !strings.Contains(message, id) {
+			missing = append(missing, id)
+		}
+	}
+	if len(missing) == 0 {
+		return message
+	}
+
+	message = strings.TrimRight(message, " \t\n")
+	switch mode {
+	case ModePrefix:
+		return "[" + strings.Join(missing, ", ") + "] " + message
+	case ModeFooter:
+		footer := FooterToken + ": " + strings.Join(missing, ", ")
+		// 이미 footer가 있으면 같은 문단에 이어서 추가
+		paragraphs := strings.Split(message, "\n\n")
+		if len(paragraphs) > 1 && isTrailerBlock(paragraphs[len(paragraphs)-1]) {
+			return message + "\n" + footer
+		}
+		return message + "\n\n" + footer
+	}
+	return message
+}
+
+// isTrailerBlock은 문단의 모든 줄이 git trailer인지 확인합니다.
+func isTrailerBlock(paragraph string) bool {
+	for _, line := range strings.Split(paragraph, "\n") {
+		if !trailerPattern.MatchString(line) {
+			return false
+		}
+	}
+	return true
+}
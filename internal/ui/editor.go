@@ -0,0 +1,64 @@
+package ui
+
+import (
+	"errors"
+	"fmt"
+	"git-ai-commit/internal/git"
+	"git-ai-commit/internal/lint"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+)
+
+// editMessage는 메시지를 임시 파일에 써서 편집기로 열고, 편집한 결과를 반환합니다.
+// 편집기는 git commit과 같이 GIT_EDITOR, core.editor, VISUAL, EDITOR 순서로 정합니다.
+// core.commentChar의 주석 문자(기본 '#')로 시작하는 줄은 지우고, 줄 앞의 들여쓰기는 유지합니다.
+func (s *Selector) editMessage(message string) (string, error) {
+	editor, err := git.GetEditor()
+	if err != nil {
+		return "", err
+	}
+	setting, err := git.GetCommentChar()
+	if err != nil {
+		return "", err
+	}
+	comment := lint.ChooseCommentChar(setting, message)
+
+	// 편집기가 커밋 메시지로 인식하도록 git과 같은 파일 이름 사용
+	dir, err := os.MkdirTemp("", "git-ai-commit-")
+	if err != nil {
+		return "", err
+	}
+	defer os.RemoveAll(dir)
+
+	path := filepath.Join(dir, "COMMIT_EDITMSG")
+	var content strings.Builder
+	content.WriteString(strings.TrimRight(message, " \t\n") + "\n\n")
+	for _, line := range strings.Split(fmt.Sprintf(s.getMessage("editor_instructions"), comment), "\n") {
+		content.WriteString(comment + " " + line + "\n")
+	}
+	if err := os.WriteFile(path, []byte(content.String()), 0600); err != nil {
+		return "", err
+	}
+
+	// 편집기 설정에 인자가 포함될 수 있으므로 git처럼 셸로 실행
+	cmd := exec.Command("sh", "-c", editor+` "$@"`, editor, path)
+	cmd.Stdin = os.Stdin
+	cmd.Stdout = os.Stdout
+	cmd.Stderr = os.Stderr
+	if err := cmd.Run(); err != nil {
+		return "", fmt.Errorf(s.getMessage("error_editor_failed"), editor, err)
+	}
+
+	edited, err := os.ReadFile(path)
+	if err != nil {
+		return "", err
+	}
+
+	result := lint.StripComments(string(edited), comment)
+	if result == "" {
+		return "", errors.New(s.getMessage("error_empty_edited_message"))
+	}
+	return result, nil
+}
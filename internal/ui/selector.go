@@ -2,14 +2,18 @@ package ui
 
 import (
 	"bufio"
+	"errors"
 	"fmt"
+	"git-ai-commit/internal/lint"
 	"os"
 	"strconv"
 	"strings"
 )
 
 // RegenerateError는 재추천 요청을 나타내는 에러입니다.
-type RegenerateError struct{}
+type RegenerateError struct {
+	Hint string // 새 후보에 반영할 사용자 요청 (비어 있으면 없음)
+}
 
 func (e *RegenerateError) Error() string {
 	return "regenerate requested"
@@ -24,24 +28,85 @@ func (e *UsePrevMessageError) Error() string {
 	return "use previous message"
 }
 
+// QuitError는 사용자가 선택 화면에서 종료를 선택했음을 나타내는 에러입니다.
+type QuitError struct {
+	Message string // 사용자에게 보여줄 메시지
+}
+
+func (e *QuitError) Error() string {
+	return e.Message
+}
+
 // Selector는 사용자가 커밋 메시지 후보 중 하나를 선택할 수 있게 하는 인터페이스입니다.
 type Selector struct {
-	lang string
+	lang   string
+	diff   string                                // "d" 키로 표시할 diff (비어 있으면 표시 안 함)
+	reader *bufio.Reader                         // 표준 입력 (재추천 후 다시 선택할 때 버퍼에 읽힌 입력을 잃지 않도록 공유)
+	linter func(message string) []lint.Violation // 후보 검사 (nil이면 검사 안 함)
 }
 
 // NewSelector는 새로운 Selector 인스턴스를 생성합니다.
 func NewSelector(lang string) *Selector {
 	return &Selector{
-		lang: lang,
+		lang:   lang,
+		reader: bufio.NewReader(os.Stdin),
 	}
 }
 
+// SetDiff는 선택 화면에서 "d" 키로 확인할 diff를 지정합니다.
+func (s *Selector) SetDiff(diff string) {
+	s.diff = diff
+}
+
+// SetLinter는 후보와 직접 입력/수정한 메시지를 검사할 함수를 지정합니다.
+// 후보의 위반 사항은 선택 화면에 표시하고, 직접 입력하거나 수정한 메시지에 위반이 있으면 사용할지 확인합니다.
+func (s *Selector) SetLinter(linter func(message string) []lint.Violation) {
+	s.linter = linter
+}
+
+// violations는 메시지의 규칙 위반 목록을 반환합니다.
+func (s *Selector) violations(message string) []lint.Violation {
+	if s.linter == nil {
+		return nil
+	}
+	return s.linter(message)
+}
+
+// confirmMessage는 직접 입력하거나 수정한 메시지에 규칙 위반이 있으면 위반 사항을 보여주고 사용할지 묻습니다.
+// 위반이 없거나 사용하겠다고 답하면 true를 반환합니다.
+func (s *Selector) confirmMessage(message string) bool {
+	violations := s.violations(message)
+	if len(violations) == 0 {
+		return true
+	}
+
+	fmt.Println()
+	for _, v := range violations {
+		fmt.Printf("⚠️  %s\n", v.Message)
+	}
+	fmt.Print(s.getMessage("prompt_use_anyway"))
+	input, err := s.reader.ReadString('\n')
+	if err != nil && input == "" {
+		return false
+	}
+	answer := strings.ToLower(strings.TrimSpace(input))
+	return answer == "y" || answer == "yes"
+}
+
 // Select는 사용자에게 후보 메시지들을 보여주고 선택을 받습니다.
+// 터미널이면 방향키로 고르는 전체 화면 선택 화면을, 아니면 번호를 입력받는 줄 단위 선택 화면을 사용합니다.
 func (s *Selector) Select(messages []string, prevMessage string) (string, error) {
 	if len(messages) == 0 {
-		return "", fmt.Errorf(s.getMessage("error_no_candidates"))
+		return "", errors.New(s.getMessage("error_no_candidates"))
+	}
+	if canUseTUI() {
+		return s.selectTUI(messages, prevMessage)
 	}
+	return s.selectLine(messages, prevMessage)
+}
 
+// selectLine은 후보 목록을 출력하고 선택을 한 줄씩 입력받습니다.
+func (s *Selector) selectLine(messages []string, prevMessage string) (string, error) {
 	fmt.Println("\n" + s.getMessage("header_candidates"))
 
 	// 이전 메시지가 있으면 표시
@@ -52,15 +117,17 @@ func (s *Selector) Select(messages []string, prevMessage string) (string, error)
 	for i, msg := range messages {
 		s.displayFormattedMessage(i+1, msg)
 	}
+	fmt.Println(s.getMessage("option_edit"))
 	fmt.Println(s.getMessage("option_custom"))
+	if s.diff != "" {
+		fmt.Println(s.getMessage("option_diff"))
+	}
 	fmt.Println(s.getMessage("option_regenerate"))
 	fmt.Println(s.getMessage("option_quit"))
 
-	reader := bufio.NewReader(os.Stdin)
-
 	for {
 		fmt.Printf("\n%s: ", s.formatPrompt(len(messages), prevMessage != ""))
-		input, err := reader.ReadString('\n')
+		input, err := s.reader.ReadString('\n')
 		if err != nil {
 			return "", fmt.Errorf(s.getMessage("error_read_input"), err)
 		}
@@ -69,7 +136,7 @@ func (s *Selector) Select(messages []string, prevMessage string) (string, error)
 
 		// 종료
 		if choice == "q" || choice == "Q" {
-			return "", fmt.Errorf(s.getMessage("error_user_quit"))
+			return "", &QuitError{Message: s.getMessage("error_user_quit")}
 		}
 
 		// 이전 메시지 사용
@@ -81,14 +148,37 @@ func (s *Selector) Select(messages []string, prevMessage string) (string, error)
 			continue
 		}
 
+		// diff 보기
+		if (choice == "d" || choice == "D") && s.diff != "" {
+			s.DisplayDiff(s.diff)
+			continue
+		}
+
 		// 재추천
 		if choice == "r" || choice == "R" {
-			return "", &RegenerateError{}
+			return s.readRegenerateHint()
+		}
+
+		// 편집기로 수정 (e1, ep 또는 e 입력 후 번호)
+		if target, ok := strings.CutPrefix(strings.ToLower(choice), "e"); ok {
+			message, err := s.editChoice(strings.TrimSpace(target), messages, prevMessage)
+			if err != nil {
+				fmt.Println(err)
+				continue
+			}
+			if s.confirmMessage(message) {
+				return message, nil
+			}
+			continue
 		}
 
 		// 직접 입력
 		if choice == "c" || choice == "C" {
-			return s.getCustomMessage()
+			message, err := s.getCustomMessage()
+			if err != nil || s.confirmMessage(message) {
+				return message, err
+			}
+			continue
 		}
 
 		// 숫자 선택
@@ -107,23 +197,63 @@ func (s *Selector) Select(messages []string, prevMessage string) (string, error)
 	}
 }
 
+// editChoice는 target 후보("p" 또는 번호)를 편집기로 열어 수정한 메시지를 반환합니다.
+// target이 비어 있으면 편집할 후보를 입력받습니다.
+func (s *Selector) editChoice(target string, messages []string, prevMessage string) (string, error) {
+	if target == "" {
+		fmt.Printf("%s: ", s.formatEditPrompt(len(messages), prevMessage != ""))
+		input, err := s.reader.ReadString('\n')
+		if err != nil {
+			return "", fmt.Errorf(s.getMessage("error_read_input"), err)
+		}
+		target = strings.ToLower(strings.TrimSpace(input))
+	}
+
+	var message string
+	if target == "p" {
+		if prevMessage == "" {
+			return "", errors.New(s.getMessage("error_no_prev_message"))
+		}
+		message = prevMessage
+	} else {
+		index, err := strconv.Atoi(target)
+		if err != nil || index < 1 || index > len(messages) {
+			return "", fmt.Errorf(s.getMessage("error_invalid_range"), len(messages))
+		}
+		message = messages[index-1]
+	}
+
+	return s.editMessage(message)
+}
+
+// readRegenerateHint는 재추천에 반영할 요청을 한 줄 입력받아 RegenerateError로 반환합니다.
+// 빈 줄을 입력하면 요청 없이 다시 생성합니다.
+func (s *Selector) readRegenerateHint() (string, error) {
+	fmt.Print(s.getMessage("prompt_regenerate_hint"))
+	input, err := s.reader.ReadString('\n')
+	if err != nil && input == "" {
+		return "", fmt.Errorf(s.getMessage("error_read_input"), err)
+	}
+	return "", &RegenerateError{Hint: strings.TrimSpace(input)}
+}
+
 // getCustomMessage는 사용자로부터 직접 커밋 메시지를 입력받습니다.
 func (s *Selector) getCustomMessage() (string, error) {
 	fmt.Println("\n" + s.getMessage("prompt_custom_message"))
 
 	var lines []string
-	reader := bufio.NewReader(os.Stdin)
 
 	for {
-		line, err := reader.ReadString('\n')
+		line, err := s.reader.ReadString('\n')
 		if err != nil {
 			return "", fmt.Errorf(s.getMessage("error_read_input"), err)
 		}
 
-		line = strings.TrimSpace(line)
+		// 본문의 들여쓰기(목록 등)는 유지하고 줄 끝 공백만 제거
+		line = strings.TrimRight(line, " \t\r\n")
 
 		// 빈 줄 입력 시 완료
-		if line == "" {
+		if strings.TrimSpace(line) == "" {
 			if len(lines) > 0 {
 				break
 			} else {
@@ -165,6 +295,50 @@ func (s *Selector) getMessage(key string) string {
 			"en": "c) Custom input",
 			"ko": "c) 사용자 직접 입력",
 		},
+		"option_edit": {
+			"en": "e) Edit a candidate in your editor (e1, e2, ... or ep)",
+			"ko": "e) 편집기로 후보 수정 후 사용 (e1, e2, ... 또는 ep)",
+		},
+		"prompt_edit_target": {
+			"en": "Candidate to edit (1-%d)",
+			"ko": "수정할 후보 (1-%d)",
+		},
+		"prompt_edit_target_with_prev": {
+			"en": "Candidate to edit (p/1-%d)",
+			"ko": "수정할 후보 (p/1-%d)",
+		},
+		"editor_instructions": {
+			"en": "Edit the commit message. Lines starting with '%[1]s' will be ignored,\nand an empty message cancels the edit.",
+			"ko": "커밋 메시지를 수정하세요. '%[1]s'(으)로 시작하는 줄은 무시되고,\n메시지를 모두 지우면 수정을 취소합니다.",
+		},
+		"error_editor_failed": {
+			"en": "Editor %q failed: %v",
+			"ko": "편집기 %q 실행 실패: %v",
+		},
+		"error_empty_edited_message": {
+			"en": "The edited message is empty. Edit cancelled.",
+			"ko": "수정한 메시지가 비어 있어 취소했습니다.",
+		},
+		"option_diff": {
+			"en": "d) Show diff",
+			"ko": "d) diff 보기",
+		},
+		"header_diff_pane": {
+			"en": "Diff",
+			"ko": "Diff",
+		},
+		"header_preview": {
+			"en": "Preview",
+			"ko": "미리보기",
+		},
+		"tui_help": {
+			"en": "↑/↓ move · Enter select · e edit · c custom · d diff · r regenerate · q quit",
+			"ko": "↑/↓ 이동 · Enter 선택 · e 편집 · c 직접 입력 · d diff · r 재추천 · q 종료",
+		},
+		"prompt_regenerate_hint": {
+			"en": "What should change? (e.g. \"mention the retry fix, scope should be api\", Enter to skip): ",
+			"ko": "무엇을 바꿀까요? (예: \"재시도 수정 언급, scope는 api\", 없으면 Enter): ",
+		},
 		"option_quit": {
 			"en": "q) Quit",
 			"ko": "q) 종료",
@@ -209,13 +383,17 @@ func (s *Selector) getMessage(key string) string {
 			"en": "No previous message available.",
 			"ko": "이전 메시지가 없습니다.",
 		},
+		"prompt_use_anyway": {
+			"en": "Use this message anyway? (y/N): ",
+			"ko": "그래도 이 메시지를 사용할까요? (y/N): ",
+		},
 		"prompt_select": {
-			"en": "Select (1-%d or c/r/q)",
-			"ko": "선택 (1-%d 또는 c/r/q)",
+			"en": "Select (1-%d or e/c/r/q)",
+			"ko": "선택 (1-%d 또는 e/c/r/q)",
 		},
 		"prompt_select_with_prev": {
-			"en": "Select (p/1-%d or c/r/q)",
-			"ko": "선택 (p/1-%d 또는 c/r/q)",
+			"en": "Select (p/1-%d or e/c/r/q)",
+			"ko": "선택 (p/1-%d 또는 e/c/r/q)",
 		},
 	}
 
@@ -238,6 +416,14 @@ func (s *Selector) formatPrompt(count int, hasPrev bool) string {
 	return fmt.Sprintf(prompt, count)
 }
 
+// formatEditPrompt는 편집할 후보를 묻는 프롬프트를 언어에 맞게 포맷팅합니다.
+func (s *Selector) formatEditPrompt(count int, hasPrev bool) string {
+	if hasPrev {
+		return fmt.Sprintf(s.getMessage("prompt_edit_target_with_prev"), count)
+	}
+	return fmt.Sprintf(s.getMessage("prompt_edit_target"), count)
+}
+
 // formatPrevMessage는 이전 메시지를 포맷팅합니다.
 func (s *Selector) formatPrevMessage(msg string) string {
 	if s.lang == "ko" {
@@ -265,6 +451,11 @@ func (s *Selector) displayFormattedMessage(index int, msg string) {
 			fmt.Println()
 		}
 	}
+
+	// 규칙 위반 사항
+	for _, v := range s.violations(msg) {
+		fmt.Printf("%s⚠️  %s\n", indent, v.Message)
+	}
 }
 
 // Add structured format for high detail level
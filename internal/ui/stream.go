@@ -0,0 +1,106 @@
+package ui
+
+import (
+	"fmt"
+	"os"
+	"strings"
+)
+
+// 작성 중인 줄을 표시할 때의 최대 길이 (rune 기준)
+const streamPartialWidth = 60
+
+// StreamPrinter는 생성 중인 커밋 메시지 후보를 실시간으로 출력합니다.
+// 완성된 후보는 제목 줄을 한 번씩 출력하고, 작성 중인 후보는 터미널일 때만
+// 같은 줄을 덮어쓰며 표시합니다. 파이프 등 터미널이 아니면 완성된 후보만 출력합니다.
+type StreamPrinter struct {
+	out      *os.File
+	tty      bool
+	printed  int  // 출력한 완성 후보 수
+	partial  bool // 작성 중인 줄이 화면에 남아 있는지 여부
+	finished bool
+}
+
+// NewStreamPrinter는 out에 출력하는 새로운 StreamPrinter 인스턴스를 생성합니다.
+// 결과를 stdout으로 출력하는 비대화형 모드에서는 os.Stderr를 전달합니다.
+func NewStreamPrinter(out *os.File) *StreamPrinter {
+	return &StreamPrinter{
+		out: out,
+		tty: isTerminal(out),
+	}
+}
+
+// Update는 지금까지 완성된 후보들과 작성 중인 후보를 받아 화면을 갱신합니다.
+func (p *StreamPrinter) Update(candidates []string, partial string) {
+	if p.finished {
+		return
+	}
+
+	for p.printed < len(candidates) {
+		p.clearPartial()
+		p.printed++
+		fmt.Fprintf(p.out, "   ✓ %d) %s\n", p.printed, firstLine(candidates[p.printed-1]))
+	}
+
+	if !p.tty {
+		return
+	}
+
+	line := lastLine(partial)
+	if line == "" {
+		p.clearPartial()
+		return
+	}
+
+	fmt.Fprintf(p.out, "\r\033[K   … %d) %s", p.printed+1, truncate(line, streamPartialWidth))
+	p.partial = true
+}
+
+// Finish는 작성 중인 줄을 지우고 스트리밍 출력을 마칩니다.
+func (p *StreamPrinter) Finish() {
+	p.clearPartial()
+	p.finished = true
+}
+
+// clearPartial은 화면에 남아 있는 작성 중인 줄을 지웁니다.
+func (p *StreamPrinter) clearPartial() {
+	if !p.partial {
+		return
+	}
+	fmt.Fprint(p.out, "\r\033[K")
+	p.partial = false
+}
+
+// isTerminal은 파일이 터미널(문자 장치)인지 확인합니다.
+func isTerminal(f *os.File) bool {
+	info, err := f.Stat()
+	if err != nil {
+		return false
+	}
+	return info.Mode()&os.ModeCharDevice != 0
+}
+
+// firstLine은 메시지의 첫 번째 줄을 반환합니다.
+func firstLine(msg string) string {
+	line, _, _ := strings.Cut(msg, "\n")
+	return strings.TrimSpace(line)
+}
+
+// lastLine은 텍스트에서 마지막으로 비어 있지 않은 줄을 반환합니다.
+func lastLine(text string) string {
+	lines := strings.Split(text, "\n")
+	for i := len(lines) - 1; i >= 0; i-- {
+		if line := strings.TrimSpace(lines[i]); line != "" {
+			return line
+		}
+	}
+	return ""
+}
+
+// truncate는 문자열을 최대 width 글자로 자르고, 잘렸으면 "..."을 붙입니다.
+func truncate(s string, width int) string {
+	runes := []rune(s)
+	if len(runes) <= width {
+		return s
+	}
+	return string(runes[:width-3]) + "..."
+}
@@ -0,0 +1,349 @@
+package ui
+
+import (
+	"fmt"
+	"git-ai-commit/internal/lint"
+	"os"
+	"strings"
+
+	"golang.org/x/term"
+)
+
+// 화면 제어용 ANSI escape 시퀀스
+const (
+	ansiAltScreenOn  = "\033[?1049h\033[?25l" // 대체 화면으로 전환하고 커서 숨김
+	ansiAltScreenOff = "\033[?25h\033[?1049l" // 커서를 보이고 원래 화면으로 복귀
+	ansiHome         = "\033[H"
+	ansiClearLine    = "\033[K"
+	ansiClearBelow   = "\033[J"
+	ansiReset        = "\033[0m"
+	ansiBold         = "\033[1m"
+	ansiDim          = "\033[2m"
+	ansiReverse      = "\033[7m"
+	ansiRed          = "\033[31m"
+	ansiGreen        = "\033[32m"
+	ansiYellow       = "\033[33m"
+	ansiCyan         = "\033[36m"
+)
+
+// tuiItem은 TUI 목록의 항목 하나입니다 (이전 메시지 또는 후보).
+type tuiItem struct {
+	key        string // 선택 키 ("p" 또는 후보 번호)
+	message    string
+	violations []lint.Violation // 규칙 위반 사항 (미리보기 아래에 표시)
+}
+
+// tui는 방향키로 후보를 고르고 미리보기/diff를 함께 보여주는 전체 화면 선택 화면입니다.
+type tui struct {
+	s        *Selector
+	items    []tuiItem
+	cursor   int
+	showDiff bool   // 미리보기 대신 diff 표시
+	scroll   int    // 미리보기/diff 스크롤 위치
+	status   string // 도움말 위에 한 줄로 표시할 메시지 (에러 등)
+}
+
+// canUseTUI는 표준 입력과 출력이 모두 터미널이라 TUI를 사용할 수 있는지 확인합니다.
+func canUseTUI() bool {
+	return term.IsTerminal(int(os.Stdin.Fd())) && term.IsTerminal(int(os.Stdout.Fd()))
+}
+
+// selectTUI는 TUI로 후보를 선택받습니다. 반환 값과 에러는 줄 단위 선택 화면과 같습니다.
+// 터미널을 raw 모드로 전환하지 못하면 줄 단위 선택 화면을 사용합니다.
+func (s *Selector) selectTUI(messages []string, prevMessage string) (string, error) {
+	t := &tui{s: s}
+	if prevMessage != "" {
+		t.items = append(t.items, tuiItem{key: "p", message: prevMessage})
+	}
+	for i, msg := range messages {
+		t.items = append(t.items, tuiItem{key: fmt.Sprint(i + 1), message: msg, violations: s.violations(msg)})
+	}
+
+	fd := int(os.Stdin.Fd())
+	state, err := term.MakeRaw(fd)
+	if err != nil {
+		return s.selectLine(messages, prevMessage)
+	}
+	fmt.Print(ansiAltScreenOn)
+
+	// suspend는 편집기나 줄 입력을 위해 화면과 터미널 모드를 원래대로 돌립니다.
+	suspended := false
+	suspend := func() {
+		if !suspended {
+			fmt.Print(ansiAltScreenOff)
+			term.Restore(fd, state)
+			suspended = true
+		}
+	}
+	resume := func() {
+		if suspended {
+			term.MakeRaw(fd)
+			fmt.Print(ansiAltScreenOn)
+			suspended = false
+		}
+	}
+	defer suspend()
+
+	for {
+		t.render()
+
+		key, err := readKey(os.Stdin)
+		if err != nil {
+			return "", fmt.Errorf(s.getMessage("error_read_input"), err)
+		}
+		t.status = ""
+
+		switch key {
+		case "up", "k":
+			t.move(-1)
+		case "down", "j", "tab":
+			t.move(1)
+		case "home":
+			t.move(-len(t.items))
+		case "end":
+			t.move(len(t.items))
+		case "pgup":
+			t.scrollBy(-t.paneHeight())
+		case "pgdown", " ":
+			t.scrollBy(t.paneHeight())
+		case "d", "D":
+			t.showDiff = !t.showDiff
+			t.scroll = 0
+			if t.showDiff && s.diff == "" {
+				t.showDiff = false
+				t.status = s.getMessage("no_changes")
+			}
+		case "enter":
+			item := t.items[t.cursor]
+			if item.key == "p" {
+				return "", &UsePrevMessageError{Message: item.message}
+			}
+			return item.message, nil
+		case "p", "P":
+			if prevMessage == "" {
+				t.status = s.getMessage("error_no_prev_message")
+				continue
+			}
+			return "", &UsePrevMessageError{Message: prevMessage}
+		case "e", "E":
+			suspend()
+			message, err := s.editMessage(t.items[t.cursor].message)
+			if err == nil && s.confirmMessage(message) {
+				return message, nil
+			}
+			resume()
+			if err != nil {
+				t.status = err.Error()
+			}
+		case "c", "C":
+			suspend()
+			message, err := s.getCustomMessage()
+			if err != nil || s.confirmMessage(message) {
+				return message, err
+			}
+			resume()
+		case "r", "R":
+			suspend()
+			return s.readRegenerateHint()
+		case "q", "Q", "esc", "ctrl-c":
+			return "", &QuitError{Message: s.getMessage("error_user_quit")}
+		default:
+			// 숫자는 줄 단위 선택 화면과 같이 바로 선택
+			if len(key) == 1 && key[0] >= '1' && key[0] <= '9' {
+				index := int(key[0] - '0')
+				if index <= len(messages) {
+					return messages[index-1], nil
+				}
+				t.status = fmt.Sprintf(s.getMessage("error_invalid_range"), len(messages))
+			}
+		}
+	}
+}
+
+// move는 커서를 delta만큼 옮기고 미리보기 스크롤을 처음으로 되돌립니다.
+func (t *tui) move(delta int) {
+	t.cursor = min(max(t.cursor+delta, 0), len(t.items)-1)
+	t.scroll = 0
+}
+
+// scrollBy는 미리보기/diff를 delta줄만큼 스크롤합니다.
+func (t *tui) scrollBy(delta int) {
+	t.scroll = min(max(t.scroll+delta, 0), max(len(t.paneLines())-t.paneHeight(), 0))
+}
+
+// size는 터미널 크기를 반환합니다. 알 수 없으면 80x24를 사용합니다.
+func (t *tui) size() (width, height int) {
+	width, height, err := term.GetSize(int(os.Stdout.Fd()))
+	if err != nil || width <= 0 || height <= 0 {
+		return 80, 24
+	}
+	return width, height
+}
+
+// listHeight는 후보 목록에 사용할 줄 수입니다. 화면이 작으면 커서 주변만 표시합니다.
+func (t *tui) listHeight() int {
+	_, height := t.size()
+	return max(min(len(t.items), height/3), 1)
+}
+
+// paneHeight는 미리보기/diff 영역의 줄 수입니다 (제목, 목록, 구분선, 상태, 도움말 제외).
+func (t *tui) paneHeight() int {
+	_, height := t.size()
+	return max(height-t.listHeight()-4, 1)
+}
+
+// paneLines는 미리보기 또는 diff 영역에 표시할 줄들을 반환합니다.
+func (t *tui) paneLines() []string {
+	if t.showDiff {
+		return strings.Split(strings.TrimRight(t.s.diff, "\n"), "\n")
+	}
+	item := t.items[t.cursor]
+	lines := strings.Split(strings.TrimRight(item.message, " \t\n"), "\n")
+	if len(item.violations) > 0 {
+		lines = append(lines, "")
+		for _, v := range item.violations {
+			lines = append(lines, "⚠ "+v.Message)
+		}
+	}
+	return lines
+}
+
+// render는 화면 전체를 다시 그립니다. raw 모드에서는 줄바꿈에 \r이 필요합니다.
+func (t *tui) render() {
+	width, _ := t.size()
+	var lines []string
+
+	lines = append(lines, ansiBold+fitWidth(t.s.getMessage("header_candidates"), width)+ansiReset)
+
+	// 커서가 보이도록 목록 범위 결정
+	listHeight := t.listHeight()
+	start := min(max(t.cursor-listHeight+1, 0), len(t.items)-listHeight)
+	for i := start; i < start+listHeight; i++ {
+		item := t.items[i]
+		label := firstLine(item.message)
+		if item.key == "p" {
+			label = t.s.formatPrevMessage(label)
+		}
+		if len(item.violations) > 0 {
+			label = "⚠ " + label
+		}
+		line := fitWidth(fmt.Sprintf(" %s) %s", item.key, label), width-2)
+		if i == t.cursor {
+			lines = append(lines, ansiReverse+"›"+line+" "+ansiReset)
+		} else {
+			lines = append(lines, " "+line)
+		}
+	}
+
+	// 구분선과 미리보기/diff
+	paneLines := t.paneLines()
+	title := t.s.getMessage("header_preview")
+	if t.showDiff {
+		title = t.s.getMessage("header_diff_pane")
+	}
+	if paneHeight := t.paneHeight(); len(paneLines) > paneHeight {
+		title += fmt.Sprintf(" (%d-%d/%d)", t.scroll+1, min(t.scroll+paneHeight, len(paneLines)), len(paneLines))
+	}
+	lines = append(lines, ansiDim+fitWidth("── "+title+" "+strings.Repeat("─", width), width)+ansiReset)
+
+	paneHeight := t.paneHeight()
+	for i := 0; i < paneHeight; i++ {
+		if t.scroll+i >= len(paneLines) {
+			lines = append(lines, "")
+			continue
+		}
+		line := fitWidth(strings.ReplaceAll(paneLines[t.scroll+i], "\t", "    "), width)
+		if t.showDiff {
+			line = colorDiffLine(line)
+		} else if strings.HasPrefix(line, "⚠ ") {
+			line = ansiYellow + line + ansiReset
+		}
+		lines = append(lines, line)
+	}
+
+	lines = append(lines, fitWidth(t.status, width))
+	lines = append(lines, ansiDim+fitWidth(t.s.getMessage("tui_help"), width)+ansiReset)
+
+	fmt.Print(ansiHome + strings.Join(lines, ansiClearLine+"\r\n") + ansiClearLine + ansiClearBelow)
+}
+
+// readKey는 키 입력 하나를 읽어 이름으로 반환합니다. 특수 키가 아니면 입력한 문자를 그대로 반환합니다.
+func readKey(f *os.File) (string, error) {
+	buf := make([]byte, 16)
+	n, err := f.Read(buf)
+	if err != nil {
+		return "", err
+	}
+
+	switch key := string(buf[:n]); key {
+	case "\033[A", "\033OA":
+		return "up", nil
+	case "\033[B", "\033OB":
+		return "down", nil
+	case "\033[5~":
+		return "pgup", nil
+	case "\033[6~":
+		return "pgdown", nil
+	case "\033[H", "\033OH", "\033[1~":
+		return "home", nil
+	case "\033[F", "\033OF", "\033[4~":
+		return "end", nil
+	case "\r", "\n":
+		return "enter", nil
+	case "\t":
+		return "tab", nil
+	case "\033":
+		return "esc", nil
+	case "\x03":
+		return "ctrl-c", nil
+	default:
+		return key, nil
+	}
+}
+
+// colorDiffLine은 diff 줄에 추가/삭제/hunk 색을 입힙니다.
+func colorDiffLine(line string) string {
+	switch {
+	case strings.HasPrefix(line, "+++"), strings.HasPrefix(line, "---"):
+		return ansiBold + line + ansiReset
+	case strings.HasPrefix(line, "+"):
+		return ansiGreen + line + ansiReset
+	case strings.HasPrefix(line, "-"):
+		return ansiRed + line + ansiReset
+	case strings.HasPrefix(line, "@@"):
+		return ansiCyan + line + ansiReset
+	}
+	return line
+}
+
+// fitWidth는 문자열을 화면 너비 width 칸에 맞게 자릅니다.
+// 한글 등 전각 문자는 두 칸으로 계산합니다.
+func fitWidth(s string, width int) string {
+	used := 0
+	for i, r := range s {
+		w := runeWidth(r)
+		if used+w > width {
+			return s[:i]
+		}
+		used += w
+	}
+	return s
+}
+
+// runeWidth는 문자가 터미널에서 차지하는 칸 수를 반환합니다.
+func runeWidth(r rune) int {
+	switch {
+	case r < 0x1100:
+		return 1
+	case r <= 0x115F, // 한글 자모
+		r >= 0x2E80 && r <= 0xA4CF, // CJK, 한글 호환 자모
+		r >= 0xAC00 && r <= 0xD7A3, // 한글 음절
+		r >= 0xF900 && r <= 0xFAFF,
+		r >= 0xFE30 && r <= 0xFE4F,
+		r >= 0xFF00 && r <= 0xFF60, // 전각 문자
+		r >= 0xFFE0 && r <= 0xFFE6,
+		r >= 0x1F300 && r <= 0x1FAFF: // 이모지
+		return 2
+	}
+	return 1
+}
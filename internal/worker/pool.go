@@ -204,6 +204,9 @@ func splitFileDiffs(diff string) []FileDiff {
 
 			currentDiff = FileDiff{Header: line}
 			inDiff = true
+		} else if inDiff && (strings.HasPrefix(line, "new file mode") || strings.HasPrefix(line, "deleted file mode")) {
+			// 새 파일/삭제 표시는 헤더에 포함 (순차 파싱과 같이 변경 내용에서는 제외)
+			currentDiff.Header += "\n" + line
 		} else if inDiff {
 			// 변경 라인 수집
 			bodyLines = append(bodyLines, line)
@@ -0,0 +1,62 @@
+package worker
+
+import (
+	"context"
+	"sync"
+)
+
+// TaskResult는 Map으로 처리한 작업 하나의 결과입니다.
+type TaskResult[R any] struct {
+	Value R     // 작업 결과
+	Err   error // 작업 에러 (성공이면 nil)
+}
+
+// indexedTask는 결과 순서를 보장하기 위해 입력 위치를 함께 전달하는 작업입니다.
+type indexedTask[T any] struct {
+	index int
+	item  T
+}
+
+// Map은 items를 workers개의 goroutine으로 병렬 처리하고, 입력 순서대로 결과를 반환합니다.
+// 한 작업이 실패해도 나머지 작업은 계속 처리하며, ctx가 취소되면 남은 작업은 ctx의 에러로 채웁니다.
+func Map[T, R any](ctx context.Context, items []T, workers int, fn func(ctx context.Context, item T) (R, error)) []TaskResult[R] {
+	results := make([]TaskResult[R], len(items))
+	if len(items) == 0 {
+		return results
+	}
+
+	if workers <= 0 {
+		workers = GetOptimalWorkerCount(len(items))
+	}
+	if workers > len(items) {
+		workers = len(items)
+	}
+
+	input := make(chan indexedTask[T], workers*2)
+	var wg sync.WaitGroup
+
+	for i := 0; i < workers; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+
+			// 각 작업은 자기 위치에만 쓰므로 잠금이 필요 없음
+			for task := range input {
+				if err := ctx.Err(); err != nil {
+					results[task.index].Err = err
+					continue
+				}
+				value, err := fn(ctx, task.item)
+				results[task.index] = TaskResult[R]{Value: value, Err: err}
+			}
+		}()
+	}
+
+	for i, item := range items {
+		input <- indexedTask[T]{index: i, item: item}
+	}
+	close(input)
+	wg.Wait()
+
+	return results
+}
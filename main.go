@@ -1,6 +1,7 @@
 package main
 
 import (
+	"errors"
 	"fmt"
 	"git-ai-commit/cmd"
 	"os"
@@ -8,7 +9,16 @@ import (
 
 func main() {
 	if err := cmd.RunWithArgs(os.Args[1:]); err != nil {
+		code := 1
+		var exitErr *cmd.ExitError
+		if errors.As(err, &exitErr) {
+			code = exitErr.Code
+			if exitErr.Err == nil {
+				os.Exit(code)
+			}
+		}
+
 		fmt.Fprintf(os.Stderr, "Error: %v\n", err)
-		os.Exit(1)
+		os.Exit(code)
 	}
 }